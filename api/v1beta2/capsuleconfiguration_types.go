@@ -29,6 +29,9 @@ type CapsuleConfigurationSpec struct {
 	// Allows to set the forbidden metadata for the worker nodes that could be patched by a Tenant.
 	// This applies only if the Tenant has an active NodeSelector, and the Owner have right to patch their nodes.
 	NodeMetadata *NodeMetadata `json:"nodeMetadata,omitempty"`
+	// Specifies the mirrors the container images must be pulled from, according to their registry.
+	// Images of the Tenant workloads are rewritten upon admission, unless the Tenant defines a mirror for the same registry.
+	RegistryMirrors []api.RegistryMirrorSpec `json:"registryMirrors,omitempty"`
 	// Toggles the TLS reconciler, the controller that is able to generate CA and certificates for the webhooks
 	// when not using an already provided CA and certificate, or when these are managed externally with Vault, or cert-manager.
 	// +kubebuilder:default=true
@@ -0,0 +1,31 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+type GatewayOptions struct {
+	// Specifies the allowed GatewayClasses for the Gateways created in the Tenant. Optional.
+	AllowedClasses *api.AllowedListSpec `json:"allowedClasses,omitempty"`
+	// Specifies the allowed hostnames of the Gateway listeners, and of the HTTPRoute, GRPCRoute, and TLSRoute resources. Optional.
+	AllowedHostnames *api.AllowedListSpec `json:"allowedHostnames,omitempty"`
+	// Toggles the ability for the Gateway API resources created in a Tenant to have a hostname wildcard.
+	AllowWildcardHostnames bool `json:"allowWildcardHostnames,omitempty"`
+	// Defines the scope of hostname collision check performed when Tenant Owners create Gateway API resources,
+	// following the same semantics of the Ingress one. Optional.
+	// +kubebuilder:default=Disabled
+	HostnameCollisionScope api.HostnameCollisionScope `json:"hostnameCollisionScope,omitempty"`
+	// Specifies the shared Gateways, outside of the Tenant Namespaces, the Routes can be attached to:
+	// when empty, the Routes can be attached to any Gateway. Optional.
+	AllowedParentGateways []GatewayReference `json:"allowedParentGateways,omitempty"`
+}
+
+type GatewayReference struct {
+	// Namespace of the Gateway.
+	Namespace string `json:"namespace"`
+	// Name of the Gateway.
+	Name string `json:"name"`
+}
@@ -4,6 +4,12 @@
 package v1beta2
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/valyala/fasttemplate"
+
 	"github.com/projectcapsule/capsule/pkg/api"
 )
 
@@ -16,11 +22,13 @@ type IngressOptions struct {
 	// Defines the scope of hostname collision check performed when Tenant Owners create Ingress with allowed hostnames.
 	//
 	//
-	// - Cluster: disallow the creation of an Ingress if the pair hostname and path is already used across the Namespaces managed by Capsule.
+	// - Cluster: disallow the creation of an Ingress if the pair hostname and path is overlapping with one already used across the Namespaces managed by Capsule.
+	//
+	// - Tenant: disallow the creation of an Ingress if the pair hostname and path is overlapping with one already used across the Namespaces of the Tenant.
 	//
-	// - Tenant: disallow the creation of an Ingress if the pair hostname and path is already used across the Namespaces of the Tenant.
+	// - Namespace: disallow the creation of an Ingress if the pair hostname and path is overlapping with one already used in the Ingress Namespace.
 	//
-	// - Namespace: disallow the creation of an Ingress if the pair hostname and path is already used in the Ingress Namespace.
+	// Wildcard hostnames, and the Prefix and Exact path types, are taken into account to detect the overlaps.
 	//
 	//
 	// Optional.
@@ -30,4 +38,97 @@ type IngressOptions struct {
 	AllowedHostnames *api.AllowedListSpec `json:"allowedHostnames,omitempty"`
 	// Toggles the ability for Ingress resources created in a Tenant to have a hostname wildcard.
 	AllowWildcardHostnames bool `json:"allowWildcardHostnames,omitempty"`
+	// Specifies the hostnames, or the domain suffixes such as *.acme.com, reserved to the Tenant across the cluster:
+	// the Ingresses and the Gateway API resources of the other Tenants cannot use them, even before the Tenant does. Optional.
+	HostnameClaims []api.HostnameClaim `json:"hostnameClaims,omitempty"`
+	// Specifies the annotations the Ingresses of the Tenant cannot use, such as the controller-specific snippet ones. Optional.
+	ForbiddenAnnotations api.ForbiddenListSpec `json:"forbiddenAnnotations,omitempty"`
+	// Specifies the only annotations the Ingresses of the Tenant can use: when set, any other annotation is forbidden,
+	// except the ones managed by kubectl and Capsule, with the kubectl.kubernetes.io/ and capsule.clastix.io/ prefixes. Optional.
+	AllowedAnnotations *api.AllowedListSpec `json:"allowedAnnotations,omitempty"`
+	// Specifies the TLS requirements of the Ingresses of the Tenant. Optional.
+	TLS *IngressTLSOptions `json:"tls,omitempty"`
+	// Specifies the defaults injected into the Ingresses of the Tenant upon creation, when missing. Optional.
+	Defaults *IngressDefaults `json:"defaults,omitempty"`
+	// Specifies the path prefixes the Tenant can serve on hostnames shared with other Tenants:
+	// the rules for the listed hostnames must only use paths within the given prefixes, with either the Prefix, or the Exact, path type.
+	// A path prefix of a hostname can't overlap with the ones assigned to other Tenants. Optional.
+	AllowedPathPrefixes []HostnamePathPrefixes `json:"allowedPathPrefixes,omitempty"`
+}
+
+type HostnamePathPrefixes struct {
+	// Hostname shared with other Tenants, matched exactly.
+	Hostname string `json:"hostname"`
+	// Path prefixes the Tenant can serve on the hostname, matched element-wise:
+	// /payments allows /payments and /payments/v1, but not /paymentsv2.
+	// +kubebuilder:validation:MinItems=1
+	PathPrefixes []string `json:"pathPrefixes"`
+}
+
+// Overlaps returns the first pair of path prefixes overlapping with the given ones for the same hostname, if any:
+// /payments is overlapping with /payments/v1, but not with /paymentsv2.
+func (in HostnamePathPrefixes) Overlaps(other HostnamePathPrefixes) (prefix, otherPrefix string, overlapping bool) {
+	if !strings.EqualFold(in.Hostname, other.Hostname) {
+		return "", "", false
+	}
+
+	for _, prefix = range in.PathPrefixes {
+		for _, otherPrefix = range other.PathPrefixes {
+			if api.IsPathPrefix(prefix, otherPrefix) || api.IsPathPrefix(otherPrefix, prefix) {
+				return prefix, otherPrefix, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+type IngressDefaults struct {
+	// Annotations added to the Ingresses not already declaring them,
+	// such as the cert-manager issuer, rate limits, or authentication URLs.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Adds a TLS block covering the hosts of the rules to the Ingresses having none.
+	TLS *IngressDefaultTLS `json:"tls,omitempty"`
+}
+
+type IngressDefaultTLS struct {
+	// Template of the name of the Secret holding the certificate.
+	// The {{ tenant.name }}, {{ namespace }}, and {{ ingress.name }} placeholders are replaced with the values of the Ingress:
+	// the rendered name must be a valid DNS-1123 subdomain.
+	// +kubebuilder:default="{{ ingress.name }}-tls"
+	SecretNameTemplate string `json:"secretNameTemplate,omitempty"`
+}
+
+// SecretName renders the name of the Secret holding the certificate of the given Ingress:
+// an error is returned for the unknown placeholders.
+func (in IngressDefaultTLS) SecretName(tenant, namespace, ingress string) (string, error) {
+	template := in.SecretNameTemplate
+	if template == "" {
+		template = "{{ ingress.name }}-tls"
+	}
+
+	values := map[string]string{
+		"tenant.name":  tenant,
+		"namespace":    namespace,
+		"ingress.name": ingress,
+	}
+
+	return fasttemplate.ExecuteFuncStringWithErr(template, "{{ ", " }}", func(w io.Writer, tag string) (int, error) {
+		value, ok := values[tag]
+		if !ok {
+			return 0, fmt.Errorf("unknown placeholder {{ %s }}", tag)
+		}
+
+		return w.Write([]byte(value))
+	})
+}
+
+type IngressTLSOptions struct {
+	// Requires the Ingresses to specify at least a TLS block.
+	Required bool `json:"required,omitempty"`
+	// Requires the TLS hosts to match the hosts of the Ingress rules:
+	// each rule host must be covered by a TLS block, and each TLS host must be used by a rule.
+	EnforceHostsMatch bool `json:"enforceHostsMatch,omitempty"`
+	// Requires the Secrets referenced by the TLS blocks to exist in the Namespace of the Ingress.
+	RequireExistingSecret bool `json:"requireExistingSecret,omitempty"`
 }
@@ -0,0 +1,44 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostnamePathPrefixesOverlaps(t *testing.T) {
+	payments := HostnamePathPrefixes{Hostname: "api.example.com", PathPrefixes: []string{"/payments"}}
+
+	for _, tc := range []struct {
+		other    HostnamePathPrefixes
+		overlaps bool
+	}{
+		{HostnamePathPrefixes{Hostname: "api.example.com", PathPrefixes: []string{"/search", "/payments/v1"}}, true},
+		{HostnamePathPrefixes{Hostname: "API.example.com", PathPrefixes: []string{"/payments/"}}, true},
+		{HostnamePathPrefixes{Hostname: "api.example.com", PathPrefixes: []string{"/"}}, true},
+		{HostnamePathPrefixes{Hostname: "api.example.com", PathPrefixes: []string{"/paymentsv2", "/search"}}, false},
+		{HostnamePathPrefixes{Hostname: "www.example.com", PathPrefixes: []string{"/payments"}}, false},
+	} {
+		_, _, overlaps := payments.Overlaps(tc.other)
+		assert.Equal(t, tc.overlaps, overlaps, "%v", tc.other)
+
+		_, _, overlaps = tc.other.Overlaps(payments)
+		assert.Equal(t, tc.overlaps, overlaps, "%v", tc.other)
+	}
+}
+
+func TestIngressDefaultTLSSecretName(t *testing.T) {
+	secretName, err := IngressDefaultTLS{}.SecretName("oil", "oil-production", "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "web-tls", secretName)
+
+	secretName, err = IngressDefaultTLS{SecretNameTemplate: "{{ tenant.name }}-{{ namespace }}-{{ ingress.name }}"}.SecretName("oil", "oil-production", "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "oil-oil-production-web", secretName)
+
+	_, err = IngressDefaultTLS{SecretNameTemplate: "{{ ingress.uid }}-tls"}.SecretName("oil", "oil-production", "web")
+	assert.Error(t, err)
+}
@@ -3,6 +3,10 @@
 
 package v1beta2
 
+import (
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
 // +kubebuilder:validation:Enum=Cordoned;Active
 type tenantState string
 
@@ -20,4 +24,31 @@ type TenantStatus struct {
 	Size uint `json:"size"`
 	// List of namespaces assigned to the Tenant.
 	Namespaces []string `json:"namespaces,omitempty"`
+	// The hostname claims of the Tenant, along with the hostnames of the Tenant Ingresses they're covering.
+	HostnameClaims []HostnameClaimStatus `json:"hostnameClaims,omitempty"`
+	// The usage of the external IPs allowed to the Tenant Services.
+	ExternalIPs *ExternalIPsStatus `json:"externalIPs,omitempty"`
+}
+
+type HostnameClaimStatus struct {
+	// The claimed hostname, or domain suffix.
+	Hostname api.HostnameClaim `json:"hostname"`
+	// The hostnames of the Tenant Ingresses covered by the claim.
+	InUse []string `json:"inUse,omitempty"`
+}
+
+type ExternalIPsStatus struct {
+	// The number of addresses of the allowed external IPs.
+	Capacity string `json:"capacity"`
+	// The number of allowed external IPs assigned to the Tenant Services.
+	Used int32 `json:"used"`
+	// The allowed external IPs assigned to the Tenant Services.
+	Assigned []ExternalIPAssignment `json:"assigned,omitempty"`
+}
+
+type ExternalIPAssignment struct {
+	// The external IP.
+	IP string `json:"ip"`
+	// The Tenant Services using the external IP, in the namespace/name form.
+	Services []string `json:"services"`
 }
@@ -26,10 +26,24 @@ type TenantSpec struct {
 	StorageClasses *api.DefaultAllowedListSpec `json:"storageClasses,omitempty"`
 	// Specifies options for the Ingress resources, such as allowed hostnames and IngressClass. Optional.
 	IngressOptions IngressOptions `json:"ingressOptions,omitempty"`
+	// Specifies options for the Gateway API resources, such as allowed hostnames, GatewayClasses, and shared Gateways. Optional.
+	GatewayOptions *GatewayOptions `json:"gatewayOptions,omitempty"`
 	// Specifies the trusted Image Registries assigned to the Tenant. Capsule assures that all Pods resources created in the Tenant can use only one of the allowed trusted registries. Optional.
 	ContainerRegistries *api.AllowedListSpec `json:"containerRegistries,omitempty"`
+	// Specifies the rules the container image references must satisfy, such as forbidding the latest tag, requiring digests for some registries,
+	// or restricting the allowed repositories within a registry. Optional.
+	ImageReferences *api.ImageReferenceSpec `json:"imageReferences,omitempty"`
+	// Specifies the public keys the container images must be signed with, according to their registry and repository.
+	// Signatures are fetched from the registry, following the cosign layout: the verified images must be referenced by digest. Optional.
+	ImageVerification []api.ImageVerificationSpec `json:"imageVerification,omitempty"`
+	// Specifies the mirrors the container images must be pulled from, according to their registry:
+	// images are rewritten upon admission, taking precedence over the mirrors defined in the Capsule configuration. Optional.
+	RegistryMirrors []api.RegistryMirrorSpec `json:"registryMirrors,omitempty"`
 	// Specifies the label to control the placement of pods on a given pool of worker nodes. All namespaces created within the Tenant will have the node selector annotation. This annotation tells the Kubernetes scheduler to place pods on the nodes having the selector label. Optional.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Specifies the scheduling constraints enforced by Capsule on the Pods of the Tenant, such as the node selector enforcement,
+	// required node affinity, tolerations, and the dedicated nodes. Optional.
+	SchedulingOptions *api.SchedulingOptions `json:"schedulingOptions,omitempty"`
 	// Specifies the NetworkPolicies assigned to the Tenant. The assigned NetworkPolicies are inherited by any namespace created in the Tenant. Optional.
 	NetworkPolicies api.NetworkPolicySpec `json:"networkPolicies,omitempty"`
 	// Specifies the resource min/max usage restrictions to the Tenant. The assigned values are inherited by any namespace created in the Tenant. Optional.
@@ -40,6 +54,9 @@ type TenantSpec struct {
 	AdditionalRoleBindings []api.AdditionalRoleBindingsSpec `json:"additionalRoleBindings,omitempty"`
 	// Specify the allowed values for the imagePullPolicies option in Pod resources. Capsule assures that all Pod resources created in the Tenant can use only one of the allowed policy. Optional.
 	ImagePullPolicies []api.ImagePullPolicySpec `json:"imagePullPolicies,omitempty"`
+	// Specifies the allowed values for the imagePullPolicy option according to the container image registry, or repository:
+	// the first matching rule applies, taking precedence over imagePullPolicies, which is used for images not matching any rule. Optional.
+	ImagePullPolicyRules []api.ImagePullPolicyRule `json:"imagePullPolicyRules,omitempty"`
 	// Specifies the allowed RuntimeClasses assigned to the Tenant.
 	// Capsule assures that all Pods resources created in the Tenant can use only one of the allowed RuntimeClasses.
 	// Optional.
@@ -49,6 +66,12 @@ type TenantSpec struct {
 	// A default value can be specified, and all the Pod resources created will inherit the declared class.
 	// Optional.
 	PriorityClasses *api.DefaultAllowedListSpec `json:"priorityClasses,omitempty"`
+	// Specifies the bounds of the values, and the preemption policies, of the Priority Classes used by the Pods of the Tenant:
+	// these apply in addition to the allowed priorityClasses, if any. Optional.
+	PriorityClassBounds *api.PriorityClassBoundsSpec `json:"priorityClassBounds,omitempty"`
+	// Specifies the bounds of the workloads of the Tenant, such as the maximum replicas of Deployments and StatefulSets,
+	// the maximum parallelism of Jobs, or the minimum interval between CronJob runs. Optional.
+	WorkloadLimits *api.WorkloadLimitsSpec `json:"workloadLimits,omitempty"`
 	// Toggling the Tenant resources cordoning, when enable resources cannot be deleted.
 	//+kubebuilder:default:=false
 	Cordoned bool `json:"cordoned,omitempty"`
@@ -4,6 +4,12 @@
 package v1beta2
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -13,6 +19,11 @@ type GlobalTenantResourceSpec struct {
 	// Defines the Tenant selector used target the tenants on which resources must be propagated.
 	TenantSelector     metav1.LabelSelector `json:"tenantSelector,omitempty"`
 	TenantResourceSpec `json:",inline"`
+	// When enabled, the effects of the GlobalTenantResource are computed and reported in the status without being applied.
+	// The changes are applied only once the preview is acknowledged, by setting the annotation
+	// capsule.clastix.io/preview-acknowledged to the acknowledgement reported by the preview.
+	// +kubebuilder:default=false
+	Preview bool `json:"preview,omitempty"`
 }
 
 // GlobalTenantResourceStatus defines the observed state of GlobalTenantResource.
@@ -21,6 +32,74 @@ type GlobalTenantResourceStatus struct {
 	SelectedTenants []string `json:"selectedTenants"`
 	// List of the replicated resources for the given TenantResource.
 	ProcessedItems ProcessedItems `json:"processedItems"`
+	// List of the replicated resources the Tenant released by removing the Capsule labels:
+	// with the CreateOnly policy, these are no longer synced, although still tracked.
+	ReleasedItems ProcessedItems `json:"releasedItems,omitempty"`
+	// Progress of the ordered rollout of the resources.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Effects of the GlobalTenantResource computed when the preview is enabled.
+	Preview *PreviewStatus `json:"preview,omitempty"`
+	// List of Namespaces reported by the acknowledged preview: the Namespaces joining, or leaving,
+	// the selected Tenants since then are reported by the PreviewNamespacesChanged condition.
+	AcknowledgedNamespaces []string `json:"acknowledgedNamespaces,omitempty"`
+	// Conditions of the GlobalTenantResource, such as the pruning being paused.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// PreviewAcknowledgedAnnotation must be set to the acknowledgement reported by the preview to apply the changes.
+	PreviewAcknowledgedAnnotation = "capsule.clastix.io/preview-acknowledged"
+	// PreviewNamespacesChangedCondition reports the Namespaces targeted by the applied changes differing from the acknowledged preview.
+	PreviewNamespacesChangedCondition = "PreviewNamespacesChanged"
+)
+
+type PreviewStatus struct {
+	// Generation of the GlobalTenantResource the preview has been computed for.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Value of the capsule.clastix.io/preview-acknowledged annotation applying the changes: it's made of the generation,
+	// and of a checksum of the selected Tenants, thus any change to them requires a new acknowledgement.
+	Acknowledgement string `json:"acknowledgement"`
+	// List of Tenants that would be addressed by the GlobalTenantResource.
+	SelectedTenants []string `json:"selectedTenants,omitempty"`
+	// List of Namespaces the resources would be replicated to.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// List of the resources that would be created, or updated.
+	Items ProcessedItems `json:"items,omitempty"`
+	// List of the resources that would be created, or updated, grouped by wave in the rollout order.
+	Waves []PreviewWaveStatus `json:"waves,omitempty"`
+	// List of the resources that would be pruned.
+	PrunedItems ProcessedItems `json:"prunedItems,omitempty"`
+}
+
+type PreviewWaveStatus struct {
+	// The rollout wave: the resources of a wave are applied once the ones of the previous waves are ready.
+	Wave int32 `json:"wave"`
+	// List of the resources of the wave that would be created, or updated.
+	Items ProcessedItems `json:"items,omitempty"`
+}
+
+// PreviewAcknowledgement returns the acknowledgement of the changes for the current generation, and the given selected Tenants:
+// the checksum covers the Tenant names only, since their Namespaces are changing along with the Tenant lifecycle.
+func (in *GlobalTenantResource) PreviewAcknowledgement(tenants []Tenant) string {
+	selection := make([]string, 0, len(tenants))
+
+	for _, tnt := range tenants {
+		selection = append(selection, tnt.GetName())
+	}
+
+	sort.Strings(selection)
+
+	checksum := sha256.Sum256([]byte(strings.Join(selection, ";")))
+
+	return strconv.FormatInt(in.GetGeneration(), 10) + "-" + hex.EncodeToString(checksum[:8])
+}
+
+// IsPreviewAcknowledged returns true when the changes have been acknowledged for the current generation,
+// and the given selected Tenants: the specification, and the selected Tenants, have not changed since then.
+func (in *GlobalTenantResource) IsPreviewAcknowledged(tenants []Tenant) bool {
+	return in.GetAnnotations()[PreviewAcknowledgedAnnotation] == in.PreviewAcknowledgement(tenants)
 }
 
 type ProcessedItems []ObjectReferenceStatus
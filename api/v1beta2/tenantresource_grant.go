@@ -0,0 +1,98 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TenantResourceGrantSpec defines the desired state of TenantResourceGrant.
+type TenantResourceGrantSpec struct {
+	// List of the Tenants allowed to select the granted resources using their TenantResource objects.
+	Tenants []string `json:"tenants"`
+	// List of the resources, living in the same Namespace of the grant, that can be selected by the consuming Tenants.
+	Resources []GrantedResource `json:"resources"`
+}
+
+type GrantedResource struct {
+	// Kind of the granted resources.
+	Kind string `json:"kind"`
+	// API version of the granted resources.
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Label selector restricting the granted resources:
+	// in case of nil value, all the resources of the given kind are granted.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Tenants",type="string",JSONPath=".spec.tenants",description="The Tenants allowed to select the granted resources"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// TenantResourceGrant allows a Tenant Owner to share resources of its Namespace with other Tenants:
+// the consuming Tenants can select the granted resources in their TenantResource objects.
+// Upon the revocation of the grant, the replicated resources are pruned.
+type TenantResourceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TenantResourceGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantResourceGrantList contains a list of TenantResourceGrant.
+type TenantResourceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantResourceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantResourceGrant{}, &TenantResourceGrantList{})
+}
+
+// Grants returns the label selectors restricting the objects of the given reference the Tenant can select,
+// and if the selection is granted at all: an object is granted when it's matched by any of the selectors.
+// The invalid selectors, rejected by the webhook upon admission, are not granting any object.
+func (in *TenantResourceGrant) Grants(tenant string, reference ObjectReference) ([]labels.Selector, bool) {
+	if in.GetNamespace() != reference.Namespace {
+		return nil, false
+	}
+
+	granted := false
+
+	for _, tnt := range in.Spec.Tenants {
+		if tnt == tenant {
+			granted = true
+
+			break
+		}
+	}
+
+	if !granted {
+		return nil, false
+	}
+
+	var selectors []labels.Selector
+
+	for _, resource := range in.Spec.Resources {
+		if resource.Kind != reference.Kind || (resource.APIVersion != "" && resource.APIVersion != reference.APIVersion) {
+			continue
+		}
+
+		if resource.Selector == nil {
+			return []labels.Selector{labels.Everything()}, true
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(resource.Selector)
+		if err != nil {
+			continue
+		}
+
+		selectors = append(selectors, selector)
+	}
+
+	return selectors, len(selectors) > 0
+}
@@ -0,0 +1,69 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestTenantResourceGrant_Grants(t *testing.T) {
+	grant := &TenantResourceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "oil-production"},
+		Spec: TenantResourceGrantSpec{
+			Tenants: []string{"gas"},
+			Resources: []GrantedResource{
+				{Kind: "ConfigMap", APIVersion: "v1"},
+				{Kind: "Secret", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}},
+				{Kind: "Secret", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"public": "true"}}},
+				{Kind: "Secret", Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "invalid", Operator: "Unknown"}}}},
+				{Kind: "Service", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}},
+				{Kind: "Service"},
+			},
+		},
+	}
+
+	reference := func(namespace, kind, apiVersion string) ObjectReference {
+		return ObjectReference{ObjectReferenceAbstract: ObjectReferenceAbstract{Kind: kind, Namespace: namespace, APIVersion: apiVersion}}
+	}
+
+	_, ok := grant.Grants("gas", reference("oil-production", "ConfigMap", "v1"))
+	assert.True(t, ok)
+
+	_, ok = grant.Grants("solar", reference("oil-production", "ConfigMap", "v1"))
+	assert.False(t, ok)
+
+	_, ok = grant.Grants("gas", reference("oil-development", "ConfigMap", "v1"))
+	assert.False(t, ok)
+
+	_, ok = grant.Grants("gas", reference("oil-production", "ConfigMap", "v2"))
+	assert.False(t, ok)
+
+	_, ok = grant.Grants("gas", reference("oil-production", "ServiceAccount", "v1"))
+	assert.False(t, ok)
+
+	matches := func(selectors []labels.Selector, set labels.Set) bool {
+		for _, selector := range selectors {
+			if selector.Matches(set) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	selectors, ok := grant.Grants("gas", reference("oil-production", "Secret", "v1"))
+	assert.True(t, ok)
+	assert.Len(t, selectors, 2)
+	assert.True(t, matches(selectors, labels.Set{"shared": "true"}))
+	assert.True(t, matches(selectors, labels.Set{"public": "true"}))
+	assert.False(t, matches(selectors, labels.Set{"shared": "false"}))
+
+	selectors, ok = grant.Grants("gas", reference("oil-production", "Service", "v1"))
+	assert.True(t, ok)
+	assert.True(t, matches(selectors, labels.Set{"shared": "false"}))
+}
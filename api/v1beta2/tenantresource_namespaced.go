@@ -22,6 +22,8 @@ type TenantResourceSpec struct {
 	PruningOnDelete *bool `json:"pruningOnDelete,omitempty"`
 	// Defines the rules to select targeting Namespace, along with the objects that must be replicated.
 	Resources []ResourceSpec `json:"resources"`
+	// Defines the safeguards applied when pruning the replicated resources no longer desired.
+	PruningPolicy *PruningPolicy `json:"pruningPolicy,omitempty"`
 }
 
 type ResourceSpec struct {
@@ -32,9 +34,40 @@ type ResourceSpec struct {
 	NamespacedItems []ObjectReference `json:"namespacedItems,omitempty"`
 	// List of raw resources that must be replicated.
 	RawItems []RawExtension `json:"rawItems,omitempty"`
+	// Defines how the replicated resources are reconciled once created.
+	// With Enforce, the resources are continuously updated to match the desired state and Tenant users cannot edit them.
+	// With CreateOnly, the resources are created once and then belong to the Tenant: these are never updated, nor protected.
+	// With Adopt, pre-existing resources are taken over rather than failing, and then belong to the Tenant as with CreateOnly.
+	// Resources replicated with CreateOnly or Adopt are released, rather than deleted, upon pruning.
+	// +kubebuilder:default=Enforce
+	SyncPolicy SyncPolicy `json:"syncPolicy,omitempty"`
 	// Besides the Capsule metadata required by TenantResource controller, defines additional metadata that must be
 	// added to the replicated resources.
 	AdditionalMetadata *api.AdditionalMetadataSpec `json:"additionalMetadata,omitempty"`
+	// Defines the rollout order of the resources: lower waves are applied first,
+	// and a wave is processed only once all the resources of the previous ones are ready.
+	// +kubebuilder:default=0
+	Wave int32 `json:"wave,omitempty"`
+	// List of checks the replicated resources must satisfy before processing the next wave.
+	// In case of no checks, the resources are considered ready as soon as they're applied.
+	ReadinessChecks []ReadinessCheck `json:"readinessChecks,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Enforce;CreateOnly;Adopt
+type SyncPolicy string
+
+const (
+	SyncPolicyEnforce    SyncPolicy = "Enforce"
+	SyncPolicyCreateOnly SyncPolicy = "CreateOnly"
+	SyncPolicyAdopt      SyncPolicy = "Adopt"
+
+	// SyncPolicyAnnotation keeps track of the SyncPolicy a replicated resource has been processed with.
+	SyncPolicyAnnotation = "capsule.clastix.io/sync-policy"
+)
+
+// IsEnforced returns true when the replicated resources must be kept in sync, and protected from Tenant users changes.
+func (s SyncPolicy) IsEnforced() bool {
+	return s == "" || s == SyncPolicyEnforce
 }
 
 // +kubebuilder:validation:XEmbeddedResource
@@ -47,6 +80,15 @@ type RawExtension struct {
 type TenantResourceStatus struct {
 	// List of the replicated resources for the given TenantResource.
 	ProcessedItems ProcessedItems `json:"processedItems"`
+	// List of the replicated resources the Tenant released by removing the Capsule labels:
+	// with the CreateOnly policy, these are no longer synced, although still tracked.
+	ReleasedItems ProcessedItems `json:"releasedItems,omitempty"`
+	// Progress of the ordered rollout of the resources.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Conditions of the TenantResource, such as the pruning being paused.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,48 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// PruningPausedCondition reports the pruning being paused due to the deletion budget being exceeded.
+	PruningPausedCondition = "PruningPaused"
+	// PreventPruningAnnotation protects a replicated resource from deletion:
+	// upon pruning, the resource is orphaned by removing the Capsule metadata.
+	PreventPruningAnnotation = "capsule.clastix.io/prevent-pruning"
+)
+
+type PruningPolicy struct {
+	// Maximum number, or percentage of the replicated resources, that can be pruned in a single reconciliation.
+	// When exceeded, the pruning is paused, and reported with the PruningPaused condition,
+	// until the desired state is fixed, or the budget is raised.
+	// +kubebuilder:validation:XIntOrString
+	MaxDeletions *intstr.IntOrString `json:"maxDeletions,omitempty"`
+	// When enabled, the pruned resources are orphaned by removing the Capsule metadata, rather than being deleted.
+	// +kubebuilder:default=false
+	Orphan bool `json:"orphan,omitempty"`
+}
+
+// ExceedsBudget returns true when the given amount of deletions, out of the total replicated resources,
+// is exceeding the deletion budget.
+func (in *PruningPolicy) ExceedsBudget(deletions, total int) bool {
+	if in == nil || in.MaxDeletions == nil || deletions == 0 {
+		return false
+	}
+
+	budget, err := intstr.GetScaledValueFromIntOrPercent(in.MaxDeletions, total, true)
+	if err != nil {
+		// An invalid budget cannot be honoured, being conservative.
+		return true
+	}
+
+	return deletions > budget
+}
+
+// IsOrphaning returns true when the pruned resources must be orphaned, rather than deleted.
+func (in *PruningPolicy) IsOrphaning() bool {
+	return in != nil && in.Orphan
+}
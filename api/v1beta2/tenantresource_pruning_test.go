@@ -0,0 +1,36 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPruningPolicy_ExceedsBudget(t *testing.T) {
+	count, percentage, invalid := intstr.FromInt32(2), intstr.FromString("10%"), intstr.FromString("ten")
+
+	type tc struct {
+		Policy    *PruningPolicy
+		Deletions int
+		Total     int
+		Expected  bool
+	}
+
+	for _, tc := range []tc{
+		{nil, 100, 100, false},
+		{&PruningPolicy{}, 100, 100, false},
+		{&PruningPolicy{MaxDeletions: &count}, 0, 100, false},
+		{&PruningPolicy{MaxDeletions: &count}, 2, 100, false},
+		{&PruningPolicy{MaxDeletions: &count}, 3, 100, true},
+		{&PruningPolicy{MaxDeletions: &percentage}, 10, 100, false},
+		{&PruningPolicy{MaxDeletions: &percentage}, 11, 100, true},
+		{&PruningPolicy{MaxDeletions: &percentage}, 1, 5, false},
+		{&PruningPolicy{MaxDeletions: &invalid}, 1, 100, true},
+	} {
+		assert.Equal(t, tc.Expected, tc.Policy.ExceedsBudget(tc.Deletions, tc.Total), "%+v", tc)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ReadinessCheck struct {
+	// Type of the status condition the replicated resources must report, such as Available, or Established.
+	ConditionType string `json:"conditionType,omitempty"`
+	// Expected status of the condition referred by the type.
+	// +kubebuilder:default="True"
+	ConditionStatus metav1.ConditionStatus `json:"conditionStatus,omitempty"`
+	// Dot separated path of the field the replicated resources must report, such as status.phase.
+	FieldPath string `json:"fieldPath,omitempty"`
+	// Expected value of the field referred by the path.
+	FieldValue string `json:"fieldValue,omitempty"`
+}
+
+// IsSatisfied returns true when the given object reports the expected condition, and field value:
+// otherwise, the reason the check is not satisfied is returned.
+func (in ReadinessCheck) IsSatisfied(obj *unstructured.Unstructured) (bool, string) {
+	if in.ConditionType != "" {
+		expected := in.ConditionStatus
+		if expected == "" {
+			expected = metav1.ConditionTrue
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+		var status string
+
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || condition["type"] != in.ConditionType {
+				continue
+			}
+
+			status = fmt.Sprint(condition["status"])
+		}
+
+		if status != string(expected) {
+			return false, fmt.Sprintf("condition %s is not %s", in.ConditionType, expected)
+		}
+	}
+
+	if in.FieldPath != "" {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(in.FieldPath, ".")...)
+		if err != nil || !found || fmt.Sprint(value) != in.FieldValue {
+			return false, fmt.Sprintf("field %s is not %s", in.FieldPath, in.FieldValue)
+		}
+	}
+
+	return true, ""
+}
+
+type RolloutStatus struct {
+	// The wave currently processed: upon a completed rollout, this is the last one.
+	CurrentWave int32 `json:"currentWave"`
+	// Reports if all the waves have been applied, and their resources satisfied the readiness checks.
+	Completed bool `json:"completed"`
+	// List of the replicated resources not yet satisfying the readiness checks, blocking the next waves.
+	PendingItems ProcessedItems `json:"pendingItems,omitempty"`
+}
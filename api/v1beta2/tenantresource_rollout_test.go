@@ -0,0 +1,43 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReadinessCheck_IsSatisfied(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": "Bound",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+				map[string]interface{}{"type": "Progressing", "status": "False"},
+			},
+		},
+	}}
+
+	type tc struct {
+		Check    ReadinessCheck
+		Expected bool
+	}
+
+	for _, tc := range []tc{
+		{ReadinessCheck{}, true},
+		{ReadinessCheck{ConditionType: "Available"}, true},
+		{ReadinessCheck{ConditionType: "Progressing"}, false},
+		{ReadinessCheck{ConditionType: "Progressing", ConditionStatus: "False"}, true},
+		{ReadinessCheck{ConditionType: "Established"}, false},
+		{ReadinessCheck{FieldPath: "status.phase", FieldValue: "Bound"}, true},
+		{ReadinessCheck{FieldPath: "status.phase", FieldValue: "Pending"}, false},
+		{ReadinessCheck{FieldPath: "status.missing", FieldValue: ""}, false},
+		{ReadinessCheck{ConditionType: "Available", FieldPath: "status.phase", FieldValue: "Pending"}, false},
+	} {
+		ok, _ := tc.Check.IsSatisfied(obj)
+		assert.Equal(t, tc.Expected, ok, "%+v", tc.Check)
+	}
+}
@@ -6,9 +6,12 @@ package resources
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	gherrors "github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -144,60 +147,200 @@ func (r *Global) reconcileNormal(ctx context.Context, tntResource *capsulev1beta
 	// upon replication and pruning, this will be updated in the status of the resource.
 	tntSet := sets.NewString()
 
-	// A TenantResource is made of several Resource sections, each one with specific options:
-	// the Status can be updated only in case of no errors across all of them to guarantee a valid and coherent status.
-	processedItems := sets.NewString()
+	for _, tnt := range tntList.Items {
+		tntSet.Insert(tnt.GetName())
+	}
 
-	for index, resource := range tntResource.Spec.Resources {
-		tenantLabel, labelErr := capsulev1beta2.GetTypeLabel(&capsulev1beta2.Tenant{})
-		if labelErr != nil {
-			log.Error(labelErr, "expected label for selection")
+	tenantLabel, labelErr := capsulev1beta2.GetTypeLabel(&capsulev1beta2.Tenant{})
+	if labelErr != nil {
+		log.Error(labelErr, "expected label for selection")
 
-			return reconcile.Result{}, labelErr
-		}
+		return reconcile.Result{}, labelErr
+	}
+	// Changes are applied only once the computed preview has been acknowledged:
+	// the preview is no longer reported once applied.
+	if tntResource.Spec.Preview && !tntResource.IsPreviewAcknowledged(tntList.Items) {
+		return r.reconcilePreview(ctx, tntResource, tntList.Items, tenantLabel)
+	}
 
-		for _, tnt := range tntList.Items {
-			tntSet.Insert(tnt.GetName())
+	// The Namespaces of the acknowledged preview are retained to report the ones joining, or leaving, the selection.
+	switch {
+	case !tntResource.Spec.Preview:
+		tntResource.Status.AcknowledgedNamespaces = nil
+
+		meta.RemoveStatusCondition(&tntResource.Status.Conditions, capsulev1beta2.PreviewNamespacesChangedCondition)
+	case tntResource.Status.Preview != nil:
+		tntResource.Status.AcknowledgedNamespaces = tntResource.Status.Preview.Namespaces
+	}
+
+	tntResource.Status.Preview = nil
 
-			items, sectionErr := r.processor.HandleSection(ctx, tnt, true, tenantLabel, index, resource)
+	tracked, released := tntResource.Status.ProcessedItems.AsSet(), sets.New[string]()
+	// A TenantResource is made of several Resource sections, each one with specific options:
+	// the Status can be updated only in case of no errors across all of them to guarantee a valid and coherent status.
+	processedItems, rollout, err := r.processor.HandleRollout(ctx, tntResource.Spec.Resources, func(index int, resource capsulev1beta2.ResourceSpec) (items []string, err error) {
+		for _, tnt := range tntList.Items {
+			tntItems, sectionErr := r.processor.HandleSection(ctx, tnt, true, tenantLabel, index, resource, tracked, released)
 			if sectionErr != nil {
 				// Upon a process error storing the last error occurred and continuing to iterate,
 				// avoid to block the whole processing.
 				err = errors.Join(err, sectionErr)
-			} else {
-				processedItems.Insert(items...)
+
+				continue
 			}
+
+			items = append(items, tntItems...)
 		}
-	}
 
+		return items, err
+	})
 	if err != nil {
 		log.Error(err, "unable to replicate the requested resources")
 
 		return reconcile.Result{}, err
 	}
 
-	if r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), sets.Set[string](processedItems)) {
-		tntResource.Status.ProcessedItems = make([]capsulev1beta2.ObjectReferenceStatus, 0, len(processedItems))
+	tntResource.Status.Rollout = rollout
+	tntResource.Status.ReleasedItems = releasedItems(released)
+
+	r.processor.HandleProcessedItems(ctx, &tntResource.Status.ProcessedItems, &tntResource.Status.Conditions, tntResource.GetGeneration(), processedItems, rollout, tntResource.Spec.PruningPolicy)
+
+	tntResource.Status.SelectedTenants = tntSet.List()
+
+	if !rollout.Completed {
+		log.Info("processing paused, waiting for the rollout")
+
+		return reconcile.Result{Requeue: true, RequeueAfter: rolloutRequeue}, nil
+	}
+
+	if tntResource.Spec.Preview {
+		reportNamespacesChanges(tntResource, processedItems)
+	}
+
+	log.Info("processing completed")
+
+	return reconcile.Result{Requeue: true, RequeueAfter: tntResource.Spec.ResyncPeriod.Duration}, nil
+}
+
+// reconcilePreview computes the effects of the GlobalTenantResource by replicating the resources with a dry-run client:
+// the outcome is reported in the status, grouped by wave, without persisting any change, nor pruning any resource.
+func (r *Global) reconcilePreview(ctx context.Context, tntResource *capsulev1beta2.GlobalTenantResource, tenants []capsulev1beta2.Tenant, tenantLabel string) (reconcile.Result, error) {
+	log := ctrllog.FromContext(ctx)
+
+	processor := Processor{
+		client: client.NewDryRunClient(r.client),
+	}
+
+	preview := &capsulev1beta2.PreviewStatus{
+		ObservedGeneration: tntResource.GetGeneration(),
+		Acknowledgement:    tntResource.PreviewAcknowledgement(tenants),
+	}
+
+	var err error
+
+	tracked, desired, namespaces := tntResource.Status.ProcessedItems.AsSet(), sets.New[string](), sets.New[string]()
 
-		for _, item := range processedItems.List() {
+	ordered, waves := rolloutWaves(tntResource.Spec.Resources)
+
+	for _, wave := range ordered {
+		items := sets.New[string]()
+
+		for _, index := range waves[wave] {
+			for _, tnt := range tenants {
+				tntItems, sectionErr := processor.HandleSection(ctx, tnt, true, tenantLabel, index, tntResource.Spec.Resources[index], tracked, sets.New[string]())
+				if sectionErr != nil {
+					err = errors.Join(err, sectionErr)
+
+					continue
+				}
+
+				items.Insert(tntItems...)
+			}
+		}
+
+		status := capsulev1beta2.PreviewWaveStatus{Wave: wave}
+
+		for _, item := range sets.List(items) {
 			if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
-				tntResource.Status.ProcessedItems = append(tntResource.Status.ProcessedItems, or)
+				status.Items = append(status.Items, or)
 			}
 		}
+
+		preview.Waves = append(preview.Waves, status)
+
+		desired = desired.Union(items)
 	}
 
-	tntResource.Status.SelectedTenants = tntSet.List()
+	if err != nil {
+		log.Error(err, "unable to compute the preview of the requested resources")
 
-	log.Info("processing completed")
+		return reconcile.Result{}, err
+	}
+
+	selectedTenants := sets.New[string]()
+
+	for _, tnt := range tenants {
+		selectedTenants.Insert(tnt.GetName())
+	}
+
+	for _, item := range sets.List(desired) {
+		if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+			preview.Items = append(preview.Items, or)
+
+			namespaces.Insert(or.Namespace)
+		}
+	}
+
+	for _, item := range sets.List(tracked.Difference(desired)) {
+		if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+			preview.PrunedItems = append(preview.PrunedItems, or)
+		}
+	}
+
+	preview.SelectedTenants = sets.List(selectedTenants)
+	preview.Namespaces = sets.List(namespaces)
+
+	tntResource.Status.Preview = preview
+
+	log.Info("preview computed, waiting for acknowledgement", "acknowledgement", preview.Acknowledgement)
 
 	return reconcile.Result{Requeue: true, RequeueAfter: tntResource.Spec.ResyncPeriod.Duration}, nil
 }
 
+// reportNamespacesChanges reports the Namespaces joining, or leaving, the selected Tenants since the preview has been acknowledged:
+// rather than requiring a new acknowledgement, the changes are applied and reported by the PreviewNamespacesChanged condition.
+func reportNamespacesChanges(tntResource *capsulev1beta2.GlobalTenantResource, processedItems sets.Set[string]) {
+	namespaces := sets.New[string]()
+
+	for _, item := range processedItems.UnsortedList() {
+		if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+			namespaces.Insert(or.Namespace)
+		}
+	}
+
+	acknowledged := sets.New(tntResource.Status.AcknowledgedNamespaces...)
+
+	added, removed := namespaces.Difference(acknowledged), acknowledged.Difference(namespaces)
+	if added.Len() == 0 && removed.Len() == 0 {
+		meta.RemoveStatusCondition(&tntResource.Status.Conditions, capsulev1beta2.PreviewNamespacesChangedCondition)
+
+		return
+	}
+
+	meta.SetStatusCondition(&tntResource.Status.Conditions, metav1.Condition{
+		Type:               capsulev1beta2.PreviewNamespacesChangedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tntResource.GetGeneration(),
+		Reason:             "NamespacesChanged",
+		Message:            fmt.Sprintf("Namespaces changed since the acknowledged preview, added: [%s], removed: [%s]", strings.Join(sets.List(added), ", "), strings.Join(sets.List(removed), ", ")),
+	})
+}
+
 func (r *Global) reconcileDelete(ctx context.Context, tntResource *capsulev1beta2.GlobalTenantResource) (reconcile.Result, error) {
 	log := ctrllog.FromContext(ctx)
 
 	if *tntResource.Spec.PruningOnDelete {
-		r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), nil)
+		r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), nil, tntResource.Spec.PruningPolicy.IsOrphaning())
 
 		controllerutil.RemoveFinalizer(tntResource, finalizer)
 	}
@@ -0,0 +1,118 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func configMapSection(name string, wave int32) capsulev1beta2.ResourceSpec {
+	return capsulev1beta2.ResourceSpec{
+		Wave: wave,
+		RawItems: []capsulev1beta2.RawExtension{
+			{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + name + `"}}`)}},
+		},
+	}
+}
+
+func TestReconcilePreview(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil", Labels: map[string]string{"energy": "fossil"}},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	gtr := &capsulev1beta2.GlobalTenantResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "fossil", Generation: 1},
+		Spec: capsulev1beta2.GlobalTenantResourceSpec{
+			TenantSelector: metav1.LabelSelector{MatchLabels: map[string]string{"energy": "fossil"}},
+			TenantResourceSpec: capsulev1beta2.TenantResourceSpec{
+				PruningOnDelete: ptr.To(false),
+				Resources:       []capsulev1beta2.ResourceSpec{configMapSection("database", 1), configMapSection("settings", 0)},
+			},
+			Preview: true,
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tnt, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "oil-production", Labels: map[string]string{tenantLabel: "oil"}}}).
+		Build()
+
+	r := &Global{client: c, processor: Processor{client: c}}
+
+	settings := types.NamespacedName{Namespace: "oil-production", Name: "settings"}
+
+	// The preview is reported by wave, with no change applied.
+	_, err := r.reconcileNormal(context.Background(), gtr)
+	assert.NoError(t, err)
+
+	preview := gtr.Status.Preview
+	assert.NotNil(t, preview)
+	assert.Equal(t, []string{"oil"}, preview.SelectedTenants)
+	assert.Equal(t, []string{"oil-production"}, preview.Namespaces)
+	assert.Len(t, preview.Items, 2)
+
+	if assert.Len(t, preview.Waves, 2) {
+		assert.Equal(t, int32(0), preview.Waves[0].Wave)
+		assert.Equal(t, "settings", preview.Waves[0].Items[0].Name)
+		assert.Equal(t, int32(1), preview.Waves[1].Wave)
+		assert.Equal(t, "database", preview.Waves[1].Items[0].Name)
+	}
+
+	assert.True(t, apierr.IsNotFound(c.Get(context.Background(), settings, &corev1.ConfigMap{})))
+
+	// Once acknowledged, the changes are applied, and the preview is cleared.
+	gtr.SetAnnotations(map[string]string{capsulev1beta2.PreviewAcknowledgedAnnotation: preview.Acknowledgement})
+
+	_, err = r.reconcileNormal(context.Background(), gtr)
+	assert.NoError(t, err)
+	assert.Nil(t, gtr.Status.Preview)
+	assert.NoError(t, c.Get(context.Background(), settings, &corev1.ConfigMap{}))
+
+	assert.Equal(t, []string{"oil-production"}, gtr.Status.AcknowledgedNamespaces)
+	assert.Nil(t, meta.FindStatusCondition(gtr.Status.Conditions, capsulev1beta2.PreviewNamespacesChangedCondition))
+
+	// A Namespace joining the selected Tenants is replicated with no further acknowledgement, and reported in the status.
+	assert.NoError(t, c.Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "oil-development", Labels: map[string]string{tenantLabel: "oil"}}}))
+
+	tnt.Status.Namespaces = append(tnt.Status.Namespaces, "oil-development")
+	assert.NoError(t, c.Update(context.Background(), tnt))
+
+	_, err = r.reconcileNormal(context.Background(), gtr)
+	assert.NoError(t, err)
+	assert.Nil(t, gtr.Status.Preview)
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "oil-development", Name: "settings"}, &corev1.ConfigMap{}))
+
+	if condition := meta.FindStatusCondition(gtr.Status.Conditions, capsulev1beta2.PreviewNamespacesChangedCondition); assert.NotNil(t, condition) {
+		assert.Contains(t, condition.Message, "added: [oil-development]")
+	}
+
+	// A Tenant joining the selection requires a new acknowledgement.
+	assert.NoError(t, c.Create(context.Background(), &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "gas", Labels: map[string]string{"energy": "fossil"}}}))
+
+	_, err = r.reconcileNormal(context.Background(), gtr)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, gtr.Status.Preview) {
+		assert.NotEqual(t, preview.Acknowledgement, gtr.Status.Preview.Acknowledgement)
+	}
+}
@@ -5,16 +5,17 @@ package resources
 
 import (
 	"context"
-	"errors"
 
 	gherrors "github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -34,9 +35,41 @@ func (r *Namespaced) SetupWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capsulev1beta2.TenantResource{}).
+		Watches(&capsulev1beta2.TenantResourceGrant{}, handler.EnqueueRequestsFromMapFunc(r.enqueueRequestFromGrant)).
 		Complete(r)
 }
 
+// enqueueRequestFromGrant triggers the reconciliation of the TenantResource objects of the granted Tenants,
+// to replicate the granted resources, or to prune them upon revocation.
+func (r *Namespaced) enqueueRequestFromGrant(ctx context.Context, object client.Object) (reqs []reconcile.Request) {
+	grant := object.(*capsulev1beta2.TenantResourceGrant) //nolint:forcetypeassert
+
+	for _, name := range grant.Spec.Tenants {
+		tnt := capsulev1beta2.Tenant{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name}, &tnt); err != nil {
+			continue
+		}
+
+		for _, ns := range tnt.Status.Namespaces {
+			resList := capsulev1beta2.TenantResourceList{}
+			if err := r.client.List(ctx, &resList, client.InNamespace(ns)); err != nil {
+				continue
+			}
+
+			for _, res := range resList.Items {
+				reqs = append(reqs, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: res.GetNamespace(),
+						Name:      res.GetName(),
+					},
+				})
+			}
+		}
+	}
+
+	return reqs
+}
+
 func (r *Namespaced) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := ctrllog.FromContext(ctx)
 
@@ -102,45 +135,33 @@ func (r *Namespaced) reconcileNormal(ctx context.Context, tntResource *capsulev1
 		return reconcile.Result{}, nil
 	}
 
-	// A TenantResource is made of several Resource sections, each one with specific options:
-	// the Status can be updated only in case of no errors across all of them to guarantee a valid and coherent status.
-	processedItems := sets.NewString()
-
 	tenantLabel, labelErr := capsulev1beta2.GetTypeLabel(&capsulev1beta2.Tenant{})
 	if labelErr != nil {
 		log.Error(labelErr, "expected label for selection")
 
 		return reconcile.Result{}, labelErr
 	}
-
-	// new empty error
-	var err error
-
-	for index, resource := range tntResource.Spec.Resources {
-		items, sectionErr := r.processor.HandleSection(ctx, tl.Items[0], false, tenantLabel, index, resource)
-		if sectionErr != nil {
-			// Upon a process error storing the last error occurred and continuing to iterate,
-			// avoid to block the whole processing.
-			err = errors.Join(err, sectionErr)
-		} else {
-			processedItems.Insert(items...)
-		}
-	}
-
+	tracked, released := tntResource.Status.ProcessedItems.AsSet(), sets.New[string]()
+	// A TenantResource is made of several Resource sections, each one with specific options:
+	// the Status can be updated only in case of no errors across all of them to guarantee a valid and coherent status.
+	processedItems, rollout, err := r.processor.HandleRollout(ctx, tntResource.Spec.Resources, func(index int, resource capsulev1beta2.ResourceSpec) ([]string, error) {
+		return r.processor.HandleSection(ctx, tl.Items[0], false, tenantLabel, index, resource, tracked, released)
+	})
 	if err != nil {
 		log.Error(err, "unable to replicate the requested resources")
 
 		return reconcile.Result{}, err
 	}
 
-	if r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), sets.Set[string](processedItems)) {
-		tntResource.Status.ProcessedItems = make([]capsulev1beta2.ObjectReferenceStatus, 0, len(processedItems))
+	tntResource.Status.Rollout = rollout
+	tntResource.Status.ReleasedItems = releasedItems(released)
 
-		for _, item := range processedItems.List() {
-			if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
-				tntResource.Status.ProcessedItems = append(tntResource.Status.ProcessedItems, or)
-			}
-		}
+	r.processor.HandleProcessedItems(ctx, &tntResource.Status.ProcessedItems, &tntResource.Status.Conditions, tntResource.GetGeneration(), processedItems, rollout, tntResource.Spec.PruningPolicy)
+
+	if !rollout.Completed {
+		log.Info("processing paused, waiting for the rollout")
+
+		return reconcile.Result{Requeue: true, RequeueAfter: rolloutRequeue}, nil
 	}
 
 	log.Info("processing completed")
@@ -152,7 +173,7 @@ func (r *Namespaced) reconcileDelete(ctx context.Context, tntResource *capsulev1
 	log := ctrllog.FromContext(ctx)
 
 	if *tntResource.Spec.PruningOnDelete {
-		r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), nil)
+		r.processor.HandlePruning(ctx, tntResource.Status.ProcessedItems.AsSet(), nil, tntResource.Spec.PruningPolicy.IsOrphaning())
 	}
 
 	controllerutil.RemoveFinalizer(tntResource, finalizer)
@@ -7,17 +7,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/valyala/fasttemplate"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -35,7 +39,51 @@ type Processor struct {
 	client client.Client
 }
 
-func (r *Processor) HandlePruning(ctx context.Context, current, desired sets.Set[string]) (updateStatus bool) {
+// HandleProcessedItems prunes the replicated resources no longer desired, updating the tracked ones accordingly.
+// Pruning is postponed when the rollout is not yet completed, or paused when exceeding the deletion budget:
+// in both cases, the resources no longer desired are still tracked.
+func (r *Processor) HandleProcessedItems(ctx context.Context, processedItems *capsulev1beta2.ProcessedItems, conditions *[]metav1.Condition, generation int64, desired sets.Set[string], rollout *capsulev1beta2.RolloutStatus, policy *capsulev1beta2.PruningPolicy) {
+	log := ctrllog.FromContext(ctx)
+
+	current, updateStatus := processedItems.AsSet(), true
+
+	switch deletions := current.Difference(desired).Len(); {
+	case rollout != nil && !rollout.Completed:
+		// The next waves are blocked: pruning is postponed until the rollout is completed,
+		// although the replicated resources must be tracked.
+		desired = desired.Union(current)
+	case policy.ExceedsBudget(deletions, current.Len()):
+		log.Info("pruning has been paused, deletion budget exceeded", "deletions", deletions)
+
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               capsulev1beta2.PruningPausedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "DeletionBudgetExceeded",
+			Message:            fmt.Sprintf("%d out of %d replicated resources would be pruned, exceeding the deletion budget", deletions, current.Len()),
+		})
+
+		desired = desired.Union(current)
+	default:
+		meta.RemoveStatusCondition(conditions, capsulev1beta2.PruningPausedCondition)
+
+		updateStatus = r.HandlePruning(ctx, current, desired, policy.IsOrphaning())
+	}
+
+	if !updateStatus {
+		return
+	}
+
+	*processedItems = make([]capsulev1beta2.ObjectReferenceStatus, 0, desired.Len())
+
+	for _, item := range sets.List(desired) {
+		if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+			*processedItems = append(*processedItems, or)
+		}
+	}
+}
+
+func (r *Processor) HandlePruning(ctx context.Context, current, desired sets.Set[string], orphan bool) (updateStatus bool) {
 	log := ctrllog.FromContext(ctx)
 
 	diff := current.Difference(desired)
@@ -58,10 +106,34 @@ func (r *Processor) HandlePruning(ctx context.Context, current, desired sets.Set
 		}
 
 		obj := unstructured.Unstructured{}
-		obj.SetNamespace(or.Namespace)
-		obj.SetName(or.Name)
 		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(or.APIVersion, or.Kind))
 
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: or.Namespace, Name: or.Name}, &obj); err != nil {
+			if apierr.IsNotFound(err) {
+				// Object may have been already deleted, we can ignore this error
+				continue
+			}
+
+			log.Error(err, "unable to retrieve resource to prune", "resource", item)
+
+			continue
+		}
+		// Resources replicated with a non enforcing policy belong to the Tenant, as well as the ones protected from pruning:
+		// rather than deleting them, these are released by removing the Capsule metadata.
+		policy := capsulev1beta2.SyncPolicy(obj.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation])
+
+		if orphan || !policy.IsEnforced() || obj.GetAnnotations()[capsulev1beta2.PreventPruningAnnotation] == "true" {
+			if err := r.release(ctx, &obj); err != nil {
+				log.Error(err, "unable to release resource", "resource", item)
+
+				continue
+			}
+
+			log.Info("resource has been released", "resource", item)
+
+			continue
+		}
+
 		if err := r.client.Delete(ctx, &obj); err != nil {
 			if apierr.IsNotFound(err) {
 				// Object may have been already deleted, we can ignore this error
@@ -79,7 +151,11 @@ func (r *Processor) HandlePruning(ctx context.Context, current, desired sets.Set
 	return updateStatus
 }
 
-func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant, allowCrossNamespaceSelection bool, tenantLabel string, resourceIndex int, spec capsulev1beta2.ResourceSpec) ([]string, error) {
+// HandleSection replicates the resources of the given section in the selected Tenant Namespaces, returning the replicated items.
+// The tracked items are the ones replicated by the previous reconciliations: with a non enforcing SyncPolicy,
+// these are not created again once deleted, since they belong to the Tenant.
+// The tracked items the Tenant released, by removing the Capsule labels, are still returned, and collected in the released set.
+func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant, allowCrossNamespaceSelection bool, tenantLabel string, resourceIndex int, spec capsulev1beta2.ResourceSpec, tracked, released sets.Set[string]) ([]string, error) {
 	log := ctrllog.FromContext(ctx)
 
 	var err error
@@ -113,15 +189,16 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 
 		return nil, err
 	}
-	// Generating additional metadata
+	// Generating additional metadata: the maps are copied, since the spec belongs to the cached object.
 	objAnnotations, objLabels := map[string]string{}, map[string]string{}
 
 	if spec.AdditionalMetadata != nil {
-		objAnnotations = spec.AdditionalMetadata.Annotations
-		objLabels = spec.AdditionalMetadata.Labels
+		maps.Copy(objAnnotations, spec.AdditionalMetadata.Annotations)
+		maps.Copy(objLabels, spec.AdditionalMetadata.Labels)
 	}
 
 	objAnnotations[tenantLabel] = tnt.GetName()
+	objAnnotations[capsulev1beta2.SyncPolicyAnnotation] = string(spec.SyncPolicy)
 
 	objLabels[Label] = fmt.Sprintf("%d", resourceIndex)
 	objLabels[tenantLabel] = tnt.GetName()
@@ -132,7 +209,11 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 
 	tntNamespaces := sets.NewString(tnt.Status.Namespaces...)
 
-	var syncErr error
+	var (
+		syncErr error
+		// mu guards the processed and released sets, populated by the concurrent replication of the namespacedItems.
+		mu sync.Mutex
+	)
 
 	codecFactory := serializer.NewCodecFactory(r.client.Scheme())
 
@@ -140,11 +221,25 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 		for nsIndex, item := range spec.NamespacedItems {
 			keysAndValues := []any{"index", nsIndex, "namespace", item.Namespace}
 			// A TenantResource is created by a TenantOwner, and potentially, they could point to a resource in a non-owned
-			// Namespace: this must be blocked by checking it this is the case.
+			// Namespace: this must be blocked by checking it this is the case, unless granted by the owning Tenant.
+			var grantSelectors []labels.Selector
+
 			if !allowCrossNamespaceSelection && !tntNamespaces.Has(item.Namespace) {
-				log.Info("skipping processing of namespacedItem, referring a Namespace that is not part of the given Tenant", keysAndValues...)
+				var grantErr error
 
-				continue
+				if grantSelectors, grantErr = r.grantSelectors(ctx, tnt.GetName(), item); grantErr != nil {
+					log.Error(grantErr, "cannot retrieve grants for namespacedItem", keysAndValues...)
+
+					syncErr = errors.Join(syncErr, grantErr)
+
+					continue
+				}
+
+				if len(grantSelectors) == 0 {
+					log.Info("skipping processing of namespacedItem, referring a Namespace that is not part of the given Tenant", keysAndValues...)
+
+					continue
+				}
 			}
 			// Namespaced Items are relying on selecting resources, rather than specifying a specific name:
 			// creating it to get used by the client List action.
@@ -177,6 +272,10 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 			// Iterating over all the retrieved objects from the resource spec to get replicated in all the selected Namespaces:
 			// in case of error during the create or update function, this will be appended to the list of errors.
 			for _, o := range objs.Items {
+				if grantSelectors != nil && !isGranted(grantSelectors, o.GetLabels()) {
+					continue
+				}
+
 				obj := o
 				obj.SetNamespace(ns.Name)
 				obj.SetOwnerReferences(nil)
@@ -189,22 +288,33 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 					kv := keysAndValues
 					kv = append(kv, "resource", fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetNamespace()))
 
-					if opErr := r.createOrUpdate(ctx, &obj, objLabels, objAnnotations); opErr != nil {
-						log.Error(opErr, "unable to sync namespacedItems", kv...)
-						errorsChan <- opErr
-
-						return
-					}
-
-					log.Info("resource has been replicated", kv...)
-
 					replicatedItem := &capsulev1beta2.ObjectReferenceStatus{}
 					replicatedItem.Name = obj.GetName()
 					replicatedItem.Kind = obj.GetKind()
 					replicatedItem.Namespace = ns.Name
 					replicatedItem.APIVersion = obj.GetAPIVersion()
 
+					opErr := r.sync(ctx, &obj, spec.SyncPolicy, tracked.Has(replicatedItem.String()), objLabels, objAnnotations)
+
+					switch {
+					case errors.Is(opErr, errReleased):
+						log.Info("resource has been released by the Tenant, skipping", kv...)
+
+						mu.Lock()
+						released.Insert(replicatedItem.String())
+						mu.Unlock()
+					case opErr != nil:
+						log.Error(opErr, "unable to sync namespacedItems", kv...)
+						errorsChan <- opErr
+
+						return
+					default:
+						log.Info("resource has been replicated", kv...)
+					}
+
+					mu.Lock()
 					processed.Insert(replicatedItem.String())
+					mu.Unlock()
 				}(obj)
 			}
 
@@ -240,20 +350,28 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 
 			obj.SetNamespace(ns.Name)
 
-			if rawErr := r.createOrUpdate(ctx, &obj, objLabels, objAnnotations); rawErr != nil {
+			replicatedItem := &capsulev1beta2.ObjectReferenceStatus{}
+			replicatedItem.Name = obj.GetName()
+			replicatedItem.Kind = obj.GetKind()
+			replicatedItem.Namespace = ns.Name
+			replicatedItem.APIVersion = obj.GetAPIVersion()
+
+			rawErr := r.sync(ctx, &obj, spec.SyncPolicy, tracked.Has(replicatedItem.String()), objLabels, objAnnotations)
+
+			switch {
+			case errors.Is(rawErr, errReleased):
+				log.Info("resource has been released by the Tenant, skipping", keysAndValues...)
+
+				released.Insert(replicatedItem.String())
+				processed.Insert(replicatedItem.String())
+			case rawErr != nil:
 				log.Info("unable to sync rawItem", keysAndValues...)
 				// In case of error processing an item in one of any selected Namespaces, storing it to report it lately
 				// to the upper call to ensure a partial sync that will be fixed by a subsequent reconciliation.
 				syncErr = errors.Join(syncErr, rawErr)
-			} else {
+			default:
 				log.Info("resource has been replicated", keysAndValues...)
 
-				replicatedItem := &capsulev1beta2.ObjectReferenceStatus{}
-				replicatedItem.Name = obj.GetName()
-				replicatedItem.Kind = obj.GetKind()
-				replicatedItem.Namespace = ns.Name
-				replicatedItem.APIVersion = obj.GetAPIVersion()
-
 				processed.Insert(replicatedItem.String())
 			}
 		}
@@ -262,6 +380,65 @@ func (r *Processor) HandleSection(ctx context.Context, tnt capsulev1beta2.Tenant
 	return processed.List(), syncErr
 }
 
+// releasedItems returns the items released by the Tenant to be reported in the status, if any.
+func releasedItems(released sets.Set[string]) (items capsulev1beta2.ProcessedItems) {
+	for _, item := range sets.List(released) {
+		if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+			items = append(items, or)
+		}
+	}
+
+	return items
+}
+
+// grantSelectors returns the selectors of the objects the given Tenant can select from another Tenant Namespace,
+// according to the TenantResourceGrant objects living there: the grants in Namespaces not belonging to any Tenant are ignored.
+func (r *Processor) grantSelectors(ctx context.Context, tenant string, item capsulev1beta2.ObjectReference) ([]labels.Selector, error) {
+	owners := capsulev1beta2.TenantList{}
+	if err := r.client.List(ctx, &owners, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(".status.namespaces", item.Namespace)}); err != nil {
+		return nil, err
+	}
+
+	if len(owners.Items) == 0 {
+		return nil, nil
+	}
+
+	grants := capsulev1beta2.TenantResourceGrantList{}
+	if err := r.client.List(ctx, &grants, client.InNamespace(item.Namespace)); err != nil {
+		return nil, err
+	}
+
+	selectors := make([]labels.Selector, 0, len(grants.Items))
+
+	for _, grant := range grants.Items {
+		if granted, ok := grant.Grants(tenant, item); ok {
+			selectors = append(selectors, granted...)
+		}
+	}
+
+	return selectors, nil
+}
+
+func isGranted(selectors []labels.Selector, objLabels map[string]string) bool {
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(objLabels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sync replicates the provided unstructured object according to the given SyncPolicy,
+// tracked reports whether the object has been already replicated by a previous reconciliation.
+func (r *Processor) sync(ctx context.Context, obj *unstructured.Unstructured, policy capsulev1beta2.SyncPolicy, tracked bool, labels map[string]string, annotations map[string]string) error {
+	if policy.IsEnforced() {
+		return r.createOrUpdate(ctx, obj, labels, annotations)
+	}
+
+	return r.createOnly(ctx, obj, policy, tracked, labels, annotations)
+}
+
 // createOrUpdate replicates the provided unstructured object to all the provided Namespaces:
 // this function mimics the CreateOrUpdate, by retrieving the object to understand if it must be created or updated,
 // along adding the additional metadata, if required.
@@ -276,29 +453,21 @@ func (r *Processor) createOrUpdate(ctx context.Context, obj *unstructured.Unstru
 	_, err = controllerutil.CreateOrUpdate(ctx, r.client, actual, func() error {
 		UID := actual.GetUID()
 		rv := actual.GetResourceVersion()
-		actual.SetUnstructuredContent(desired.Object)
-
-		combinedLabels := obj.GetLabels()
-		if combinedLabels == nil {
-			combinedLabels = make(map[string]string)
-		}
+		// The pruning protection is set on the replicated resource by cluster administrators,
+		// it must be preserved although not part of the desired state.
+		preventPruning, protected := actual.GetAnnotations()[capsulev1beta2.PreventPruningAnnotation]
 
-		for key, value := range labels {
-			combinedLabels[key] = value
-		}
+		actual.SetUnstructuredContent(desired.Object)
 
-		actual.SetLabels(combinedLabels)
+		mergeMetadata(actual, labels, annotations)
 
-		combinedAnnotations := obj.GetAnnotations()
-		if combinedAnnotations == nil {
-			combinedAnnotations = make(map[string]string)
-		}
+		if protected {
+			combinedAnnotations := actual.GetAnnotations()
+			combinedAnnotations[capsulev1beta2.PreventPruningAnnotation] = preventPruning
 
-		for key, value := range annotations {
-			combinedAnnotations[key] = value
+			actual.SetAnnotations(combinedAnnotations)
 		}
 
-		actual.SetAnnotations(combinedAnnotations)
 		actual.SetResourceVersion(rv)
 		actual.SetUID(UID)
 
@@ -307,3 +476,106 @@ func (r *Processor) createOrUpdate(ctx context.Context, obj *unstructured.Unstru
 
 	return err
 }
+
+// errReleased is returned by createOnly for the tracked objects the Tenant released by removing the Capsule labels:
+// these are skipped, rather than failing the replication, and reported in the status.
+var errReleased = errors.New("object has been released by the Tenant")
+
+// createOnly creates the provided unstructured object if missing, without any further update:
+// once created, the object belongs to the Tenant, and it's not created again if deleted.
+// An already existing object not created by Capsule is taken over only with the Adopt policy, by adding the Capsule metadata
+// and leaving its content untouched: otherwise, an error is returned to avoid silently claiming Tenant resources.
+func (r *Processor) createOnly(ctx context.Context, obj *unstructured.Unstructured, policy capsulev1beta2.SyncPolicy, tracked bool, labels map[string]string, annotations map[string]string) error {
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, actual)
+
+	switch {
+	case apierr.IsNotFound(err) && tracked:
+		return nil
+	case apierr.IsNotFound(err):
+		desired := obj.DeepCopy()
+		mergeMetadata(desired, labels, annotations)
+
+		return r.client.Create(ctx, desired)
+	case err != nil:
+		return err
+	}
+
+	if _, ok := actual.GetLabels()[Label]; ok {
+		// The content belongs to the Tenant, although the SyncPolicy annotation must reflect the current one,
+		// since it drives the protection of the object, and its release upon pruning.
+		if actual.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation] == annotations[capsulev1beta2.SyncPolicyAnnotation] {
+			return nil
+		}
+
+		patch := client.MergeFrom(actual.DeepCopy())
+
+		mergeMetadata(actual, nil, map[string]string{capsulev1beta2.SyncPolicyAnnotation: annotations[capsulev1beta2.SyncPolicyAnnotation]})
+
+		return r.client.Patch(ctx, actual, patch)
+	}
+
+	if policy != capsulev1beta2.SyncPolicyAdopt {
+		// The Capsule labels have been removed by the Tenant from an object replicated by a previous reconciliation.
+		if tracked {
+			return errReleased
+		}
+
+		return fmt.Errorf("%s %s/%s already exists and is not managed by Capsule, use the %s sync policy to take it over", actual.GetKind(), actual.GetNamespace(), actual.GetName(), capsulev1beta2.SyncPolicyAdopt)
+	}
+
+	patch := client.MergeFrom(actual.DeepCopy())
+
+	mergeMetadata(actual, labels, annotations)
+
+	return r.client.Patch(ctx, actual, patch)
+}
+
+// release removes the Capsule metadata from a replicated object,
+// that is no longer tracked by a TenantResource, without deleting it.
+func (r *Processor) release(ctx context.Context, obj *unstructured.Unstructured) error {
+	tenantLabel, err := capsulev1beta2.GetTypeLabel(&capsulev1beta2.Tenant{})
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(obj.DeepCopy())
+
+	objLabels, objAnnotations := obj.GetLabels(), obj.GetAnnotations()
+
+	delete(objLabels, Label)
+	delete(objLabels, tenantLabel)
+	delete(objAnnotations, tenantLabel)
+	delete(objAnnotations, capsulev1beta2.SyncPolicyAnnotation)
+
+	obj.SetLabels(objLabels)
+	obj.SetAnnotations(objAnnotations)
+
+	return r.client.Patch(ctx, obj, patch)
+}
+
+func mergeMetadata(obj *unstructured.Unstructured, labels map[string]string, annotations map[string]string) {
+	combinedLabels := obj.GetLabels()
+	if combinedLabels == nil {
+		combinedLabels = make(map[string]string)
+	}
+
+	for key, value := range labels {
+		combinedLabels[key] = value
+	}
+
+	obj.SetLabels(combinedLabels)
+
+	combinedAnnotations := obj.GetAnnotations()
+	if combinedAnnotations == nil {
+		combinedAnnotations = make(map[string]string)
+	}
+
+	for key, value := range annotations {
+		combinedAnnotations[key] = value
+	}
+
+	obj.SetAnnotations(combinedAnnotations)
+}
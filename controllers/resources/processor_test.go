@@ -0,0 +1,216 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const tenantLabel = "capsule.clastix.io/tenant"
+
+func syncPolicyFixture(t *testing.T, objs ...client.Object) (*Processor, capsulev1beta2.Tenant) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "oil-production", Labels: map[string]string{tenantLabel: "oil"}}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, ns)...).Build()
+
+	return &Processor{client: c}, tnt
+}
+
+func syncPolicySection(policy capsulev1beta2.SyncPolicy, value string) capsulev1beta2.ResourceSpec {
+	return capsulev1beta2.ResourceSpec{
+		SyncPolicy: policy,
+		RawItems: []capsulev1beta2.RawExtension{
+			{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"},"data":{"key":"` + value + `"}}`)}},
+		},
+	}
+}
+
+func getSettings(t *testing.T, p *Processor) (*corev1.ConfigMap, error) {
+	t.Helper()
+
+	cm := &corev1.ConfigMap{}
+
+	return cm, p.client.Get(context.Background(), types.NamespacedName{Namespace: "oil-production", Name: "settings"}, cm)
+}
+
+func TestHandleSectionEnforce(t *testing.T) {
+	p, tnt := syncPolicyFixture(t)
+
+	items, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyEnforce, "v1"), sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", cm.Data["key"])
+	assert.Equal(t, string(capsulev1beta2.SyncPolicyEnforce), cm.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation])
+
+	// Changes are reverted to the desired state.
+	cm.Data["key"] = "changed"
+	assert.NoError(t, p.client.Update(context.Background(), cm))
+
+	_, err = p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyEnforce, "v1"), sets.New(items...), sets.New[string]())
+	assert.NoError(t, err)
+
+	cm, err = getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", cm.Data["key"])
+
+	// Deleted objects are created again.
+	assert.NoError(t, p.client.Delete(context.Background(), cm))
+
+	_, err = p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyEnforce, "v1"), sets.New(items...), sets.New[string]())
+	assert.NoError(t, err)
+
+	_, err = getSettings(t, p)
+	assert.NoError(t, err)
+}
+
+func TestHandleSectionCreateOnly(t *testing.T) {
+	p, tnt := syncPolicyFixture(t)
+
+	items, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v1"), sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, string(capsulev1beta2.SyncPolicyCreateOnly), cm.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation])
+
+	// Once created, the object belongs to the Tenant and is not updated.
+	_, err = p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v2"), sets.New(items...), sets.New[string]())
+	assert.NoError(t, err)
+
+	cm, err = getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", cm.Data["key"])
+
+	// The tracked objects deleted by the Tenant are not created again, although still tracked.
+	assert.NoError(t, p.client.Delete(context.Background(), cm))
+
+	resynced, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v1"), sets.New(items...), sets.New[string]())
+	assert.NoError(t, err)
+	assert.Equal(t, items, resynced)
+
+	_, err = getSettings(t, p)
+	assert.True(t, apierr.IsNotFound(err))
+}
+
+func TestHandleSectionCreateOnlyReleased(t *testing.T) {
+	p, tnt := syncPolicyFixture(t)
+
+	items, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v1"), sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+
+	// The Tenant releases the object by removing the Capsule labels: it's skipped, and reported as released.
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+
+	cm.SetLabels(nil)
+	assert.NoError(t, p.client.Update(context.Background(), cm))
+
+	released := sets.New[string]()
+
+	resynced, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v2"), sets.New(items...), released)
+	assert.NoError(t, err)
+	assert.Equal(t, items, resynced)
+	assert.Equal(t, sets.New(items...), released)
+
+	cm, err = getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", cm.Data["key"])
+	assert.NotContains(t, cm.GetLabels(), Label)
+}
+
+func TestHandleSectionAdditionalMetadata(t *testing.T) {
+	p, tnt := syncPolicyFixture(t)
+
+	section := syncPolicySection(capsulev1beta2.SyncPolicyEnforce, "v1")
+	section.AdditionalMetadata = &api.AdditionalMetadataSpec{Labels: map[string]string{"env": "prod"}}
+
+	_, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, section, sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+	// The additional metadata of the spec are not mutated by the Capsule ones.
+	assert.Equal(t, map[string]string{"env": "prod"}, section.AdditionalMetadata.Labels)
+	assert.Nil(t, section.AdditionalMetadata.Annotations)
+
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", cm.GetLabels()["env"])
+}
+
+func TestHandleSectionCreateOnlyUnmanaged(t *testing.T) {
+	p, tnt := syncPolicyFixture(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "oil-production"},
+		Data:       map[string]string{"key": "tenant"},
+	})
+
+	_, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyCreateOnly, "v1"), sets.New[string](), sets.New[string]())
+	assert.Error(t, err)
+
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+	assert.NotContains(t, cm.GetLabels(), Label)
+}
+
+func TestHandleSectionAdopt(t *testing.T) {
+	p, tnt := syncPolicyFixture(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "oil-production"},
+		Data:       map[string]string{"key": "tenant"},
+	})
+
+	items, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyAdopt, "v1"), sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	// The pre-existing object is taken over, leaving its content untouched.
+	cm, err := getSettings(t, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant", cm.Data["key"])
+	assert.Equal(t, "0", cm.GetLabels()[Label])
+	assert.Equal(t, string(capsulev1beta2.SyncPolicyAdopt), cm.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation])
+}
+
+func TestHandleSectionSyncPolicyChange(t *testing.T) {
+	p, tnt := syncPolicyFixture(t)
+
+	items, err := p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(capsulev1beta2.SyncPolicyEnforce, "v1"), sets.New[string](), sets.New[string]())
+	assert.NoError(t, err)
+
+	for _, policy := range []capsulev1beta2.SyncPolicy{capsulev1beta2.SyncPolicyCreateOnly, capsulev1beta2.SyncPolicyAdopt} {
+		_, err = p.HandleSection(context.Background(), tnt, false, tenantLabel, 0, syncPolicySection(policy, "v2"), sets.New(items...), sets.New[string]())
+		assert.NoError(t, err)
+
+		cm, err := getSettings(t, p)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", cm.Data["key"])
+		assert.Equal(t, string(policy), cm.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation])
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// rolloutRequeue is the period after which a blocked rollout is evaluated again,
+// without waiting for the resync period.
+const rolloutRequeue = 10 * time.Second
+
+// sectionFunc replicates the resource section with the given index, returning the replicated items.
+type sectionFunc func(index int, spec capsulev1beta2.ResourceSpec) ([]string, error)
+
+// HandleRollout processes the resource sections wave by wave, in ascending order:
+// a wave is processed only if all the sections of the previous ones have been replicated with no errors,
+// and their items are satisfying the readiness checks.
+func (r *Processor) HandleRollout(ctx context.Context, resources []capsulev1beta2.ResourceSpec, section sectionFunc) (processed sets.Set[string], rollout *capsulev1beta2.RolloutStatus, err error) {
+	log := ctrllog.FromContext(ctx)
+
+	processed, rollout = sets.New[string](), &capsulev1beta2.RolloutStatus{}
+
+	ordered, waves := rolloutWaves(resources)
+
+	for _, wave := range ordered {
+		rollout.CurrentWave = wave
+
+		pending := sets.New[string]()
+
+		for _, index := range waves[wave] {
+			items, sectionErr := section(index, resources[index])
+			if sectionErr != nil {
+				// Upon a process error storing the last error occurred and continuing to iterate,
+				// avoid to block the whole processing.
+				err = errors.Join(err, sectionErr)
+
+				continue
+			}
+
+			processed.Insert(items...)
+
+			pending.Insert(r.pendingItems(ctx, items, resources[index].SyncPolicy, resources[index].ReadinessChecks)...)
+		}
+
+		if err != nil {
+			return processed, rollout, err
+		}
+
+		if pending.Len() > 0 {
+			log.Info("rollout is waiting for resources to be ready", "wave", wave, "pending", pending.Len())
+
+			for _, item := range sets.List(pending) {
+				if or := (capsulev1beta2.ObjectReferenceStatus{}); or.ParseFromString(item) == nil {
+					rollout.PendingItems = append(rollout.PendingItems, or)
+				}
+			}
+
+			return processed, rollout, nil
+		}
+	}
+
+	rollout.Completed = true
+
+	return processed, rollout, nil
+}
+
+// rolloutWaves returns the waves of the given resource sections in ascending order,
+// along with the indexes of the sections belonging to each of them.
+func rolloutWaves(resources []capsulev1beta2.ResourceSpec) (ordered []int32, waves map[int32][]int) {
+	waves = map[int32][]int{}
+
+	for index, resource := range resources {
+		waves[resource.Wave] = append(waves[resource.Wave], index)
+	}
+
+	ordered = make([]int32, 0, len(waves))
+	for wave := range waves {
+		ordered = append(ordered, wave)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i] < ordered[j] })
+
+	return ordered, waves
+}
+
+// pendingItems returns the replicated items not satisfying the provided readiness checks.
+// With a non enforcing SyncPolicy, the items deleted by the Tenant are not created again, and are not blocking the rollout.
+func (r *Processor) pendingItems(ctx context.Context, items []string, policy capsulev1beta2.SyncPolicy, checks []capsulev1beta2.ReadinessCheck) (pending []string) {
+	log := ctrllog.FromContext(ctx)
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	for _, item := range items {
+		or := capsulev1beta2.ObjectReferenceStatus{}
+		if err := or.ParseFromString(item); err != nil {
+			log.Error(err, "unable to parse resource to check", "resource", item)
+
+			pending = append(pending, item)
+
+			continue
+		}
+
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(or.APIVersion, or.Kind))
+
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: or.Namespace, Name: or.Name}, &obj); err != nil {
+			if apierr.IsNotFound(err) && !policy.IsEnforced() {
+				continue
+			}
+
+			log.Error(err, "unable to retrieve resource to check", "resource", item)
+
+			pending = append(pending, item)
+
+			continue
+		}
+
+		for _, check := range checks {
+			if ok, reason := check.IsSatisfied(&obj); !ok {
+				log.Info("resource is not ready", "resource", item, "reason", reason)
+
+				pending = append(pending, item)
+
+				break
+			}
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,120 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/indexer/service"
+)
+
+// ExternalIPsManager reports the capacity of the external IP pools of the Tenants, and which of their Services
+// each assigned address belongs to: only the Services using external IPs trigger a reconciliation.
+type ExternalIPsManager struct {
+	client.Client
+	Log logr.Logger
+}
+
+func (r *ExternalIPsManager) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tenant-external-ips").
+		For(&capsulev1beta2.Tenant{}).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.enqueueFromService()), builder.WithPredicates(externalIPsPredicate())).
+		Complete(r)
+}
+
+// externalIPsPredicate filters the events of the Services using external IPs, before or after an update.
+func externalIPsPredicate() predicate.Funcs {
+	hasExternalIPs := func(object client.Object) bool {
+		svc, ok := object.(*corev1.Service)
+
+		return ok && len(svc.Spec.ExternalIPs) > 0
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasExternalIPs(e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasExternalIPs(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return hasExternalIPs(e.ObjectOld) || hasExternalIPs(e.ObjectNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return hasExternalIPs(e.Object)
+		},
+	}
+}
+
+func (r *ExternalIPsManager) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	return reconcileStatus(ctx, r.Client, r.Log, request, "external IPs", r.syncExternalIPs)
+}
+
+// syncExternalIPs reports the usage of the external IPs allowed to the Tenant in its status,
+// along with the Tenant Services each of them is assigned to.
+func (r *ExternalIPsManager) syncExternalIPs(ctx context.Context, tnt *capsulev1beta2.Tenant) error {
+	var status *capsulev1beta2.ExternalIPsStatus
+
+	if spec := tnt.Spec.ServiceOptions; spec != nil && spec.ExternalServiceIPs != nil {
+		assigned := make(map[string][]string)
+
+		for _, ns := range tnt.Status.Namespaces {
+			services := &corev1.ServiceList{}
+			if err := r.List(ctx, services, client.InNamespace(ns)); err != nil {
+				return err
+			}
+
+			for _, svc := range services.Items {
+				for _, externalIP := range svc.Spec.ExternalIPs {
+					if ip := net.ParseIP(externalIP); ip == nil || !spec.ExternalServiceIPs.Contains(ip) {
+						continue
+					}
+
+					key := service.CanonicalIP(externalIP)
+					assigned[key] = append(assigned[key], svc.GetNamespace()+"/"+svc.GetName())
+				}
+			}
+		}
+
+		status = &capsulev1beta2.ExternalIPsStatus{
+			Capacity: spec.ExternalServiceIPs.Capacity().String(),
+			Used:     int32(len(assigned)),
+		}
+
+		for ip, services := range assigned {
+			sort.Strings(services)
+
+			status.Assigned = append(status.Assigned, capsulev1beta2.ExternalIPAssignment{IP: ip, Services: services})
+		}
+
+		sort.Slice(status.Assigned, func(i, j int) bool {
+			return status.Assigned[i].IP < status.Assigned[j].IP
+		})
+	}
+
+	return updateStatus(ctx, r.Client, tnt.GetName(), func(tntStatus *capsulev1beta2.TenantStatus) **capsulev1beta2.ExternalIPsStatus {
+		return &tntStatus.ExternalIPs
+	}, status)
+}
+
+// enqueueFromService enqueues the Tenant owning the Namespace of the given Service,
+// when allowed to use external IPs, or still reporting their usage.
+func (r *ExternalIPsManager) enqueueFromService() handler.MapFunc {
+	return enqueueNamespaceTenant(r.Client, r.Log, func(tnt *capsulev1beta2.Tenant) bool {
+		return (tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.ExternalServiceIPs != nil) || tnt.Status.ExternalIPs != nil
+	})
+}
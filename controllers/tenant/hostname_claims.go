@@ -0,0 +1,160 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/webhook/gateway"
+)
+
+// hostnameClaimsGatewayKinds are the Gateway API kinds whose hostnames are reported in the claims usage, when served.
+var hostnameClaimsGatewayKinds = []string{"Gateway", "HTTPRoute", "GRPCRoute", "TLSRoute"}
+
+// HostnameClaimsManager reports, for each hostname claim of the Tenants, the hostnames of their Ingresses,
+// and Gateway API resources, covered by it: the watched Gateway API kinds are the ones served by the cluster upon setup.
+type HostnameClaimsManager struct {
+	client.Client
+	Log logr.Logger
+
+	// gatewayKinds are the Gateway API kinds served by the cluster, discovered upon setup.
+	gatewayKinds []schema.GroupVersionKind
+}
+
+func (r *HostnameClaimsManager) SetupWithManager(mgr ctrl.Manager) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		Named("tenant-hostname-claims").
+		For(&capsulev1beta2.Tenant{}).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(r.enqueueFromRoute()), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	for _, kind := range hostnameClaimsGatewayKinds {
+		mapping, err := mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: gateway.GroupName, Kind: kind})
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				r.Log.Info("Skipping the hostname claims of the Gateway API kind, not served by the cluster", "kind", kind)
+
+				continue
+			}
+
+			return err
+		}
+
+		r.gatewayKinds = append(r.gatewayKinds, mapping.GroupVersionKind)
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(mapping.GroupVersionKind)
+
+		bldr = bldr.Watches(obj, handler.EnqueueRequestsFromMapFunc(r.enqueueFromRoute()), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+
+	return bldr.Complete(r)
+}
+
+func (r *HostnameClaimsManager) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	return reconcileStatus(ctx, r.Client, r.Log, request, "hostname claims", r.syncHostnameClaims)
+}
+
+// syncHostnameClaims reports the hostname claims of the Tenant in its status,
+// along with the hostnames of the Tenant Ingresses, and Gateway API resources, covered by each of them.
+func (r *HostnameClaimsManager) syncHostnameClaims(ctx context.Context, tnt *capsulev1beta2.Tenant) error {
+	claims := make([]capsulev1beta2.HostnameClaimStatus, 0, len(tnt.Spec.IngressOptions.HostnameClaims))
+
+	if len(tnt.Spec.IngressOptions.HostnameClaims) > 0 {
+		hostnames, err := r.hostnames(ctx, tnt)
+		if err != nil {
+			return err
+		}
+
+		for _, claim := range tnt.Spec.IngressOptions.HostnameClaims {
+			status := capsulev1beta2.HostnameClaimStatus{Hostname: claim}
+
+			for hostname := range hostnames {
+				if claim.Overlaps(api.HostnameClaim(hostname)) {
+					status.InUse = append(status.InUse, hostname)
+				}
+			}
+
+			sort.Strings(status.InUse)
+
+			claims = append(claims, status)
+		}
+	}
+
+	if len(claims) == 0 {
+		claims = nil
+	}
+
+	return updateStatus(ctx, r.Client, tnt.GetName(), func(status *capsulev1beta2.TenantStatus) *[]capsulev1beta2.HostnameClaimStatus {
+		return &status.HostnameClaims
+	}, claims)
+}
+
+// hostnames returns the hostnames used by the Ingresses, and the Gateway API resources, of the Tenant Namespaces.
+func (r *HostnameClaimsManager) hostnames(ctx context.Context, tnt *capsulev1beta2.Tenant) (sets.Set[string], error) {
+	hostnames := sets.New[string]()
+
+	for _, ns := range tnt.Status.Namespaces {
+		ingresses := &networkingv1.IngressList{}
+		if err := r.List(ctx, ingresses, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+
+		for _, ingress := range ingresses.Items {
+			for _, rule := range ingress.Spec.Rules {
+				if rule.Host != "" {
+					hostnames.Insert(rule.Host)
+				}
+			}
+		}
+
+		for _, gvk := range r.gatewayKinds {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+			if err := r.List(ctx, list, client.InNamespace(ns)); err != nil {
+				return nil, err
+			}
+
+			for i := range list.Items {
+				resource, err := gateway.FromUnstructured(&list.Items[i])
+				if err != nil {
+					return nil, err
+				}
+
+				resource.Kind = gvk.Kind
+
+				for hostname := range resource.Hostnames() {
+					if hostname != "" {
+						hostnames.Insert(hostname)
+					}
+				}
+			}
+		}
+	}
+
+	return hostnames, nil
+}
+
+// enqueueFromRoute enqueues the Tenant owning the Namespace of the given Ingress, or Gateway API resource,
+// when claiming hostnames, or still reporting claims.
+func (r *HostnameClaimsManager) enqueueFromRoute() handler.MapFunc {
+	return enqueueNamespaceTenant(r.Client, r.Log, func(tnt *capsulev1beta2.Tenant) bool {
+		return len(tnt.Spec.IngressOptions.HostnameClaims) > 0 || len(tnt.Status.HostnameClaims) > 0
+	})
+}
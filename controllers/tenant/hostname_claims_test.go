@@ -0,0 +1,77 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/webhook/gateway"
+)
+
+func TestSyncHostnameClaims(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	httpRoute := schema.GroupVersionKind{Group: gateway.GroupName, Version: "v1", Kind: "HTTPRoute"}
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec: capsulev1beta2.TenantSpec{
+			IngressOptions: capsulev1beta2.IngressOptions{HostnameClaims: []api.HostnameClaim{"*.oil.acme.com", "gas.acme.com"}},
+		},
+		Status: capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+			{Host: "www.oil.acme.com"},
+			{Host: "www.acme.com"},
+			{},
+		}},
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"hostnames": []interface{}{"api.oil.acme.com", "gas.acme.com"}},
+	}}
+	route.SetGroupVersionKind(httpRoute)
+	route.SetName("api")
+	route.SetNamespace("oil-production")
+
+	r := &HostnameClaimsManager{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(tnt, ingress, route).
+			WithStatusSubresource(&capsulev1beta2.Tenant{}).
+			Build(),
+		Log:          logr.Discard(),
+		gatewayKinds: []schema.GroupVersionKind{httpRoute},
+	}
+
+	require.NoError(t, r.syncHostnameClaims(context.Background(), tnt))
+
+	found := &capsulev1beta2.Tenant{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "oil"}, found))
+
+	assert.Equal(t, []capsulev1beta2.HostnameClaimStatus{
+		{Hostname: "*.oil.acme.com", InUse: []string{"api.oil.acme.com", "www.oil.acme.com"}},
+		{Hostname: "gas.acme.com", InUse: []string{"gas.acme.com"}},
+	}, found.Status.HostnameClaims)
+}
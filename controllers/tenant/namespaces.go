@@ -73,6 +73,12 @@ func (r *Manager) syncNamespaceMetadata(ctx context.Context, namespace string, t
 				}
 			}
 
+			if tnt.Spec.PodOptions != nil && tnt.Spec.PodOptions.PodSecurityAdmission != nil {
+				for k, v := range tnt.Spec.PodOptions.PodSecurityAdmission.Labels() {
+					labels[k] = v
+				}
+			}
+
 			if tnt.Spec.NodeSelector != nil {
 				annotations = utils.BuildNodeSelector(tnt, annotations)
 			}
@@ -0,0 +1,339 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const (
+	// dedicatedNodeLabel marks the nodes dedicated to a Tenant, with its name.
+	dedicatedNodeLabel = "capsule.clastix.io/dedicated-tenant"
+	// dedicatedNodeAnnotation keeps track of the labels and taints added by Capsule to a dedicated node,
+	// in order to remove them once the node is no longer dedicated to the Tenant.
+	dedicatedNodeAnnotation = "capsule.clastix.io/dedicated-tenant-metadata"
+)
+
+// DedicatedNodesManager labels and taints the nodes dedicated to the Tenants, releasing them once no longer selected:
+// the nodes are watched for the changes of their metadata and taints only, ignoring the status heartbeats.
+type DedicatedNodesManager struct {
+	client.Client
+	Log logr.Logger
+}
+
+func (r *DedicatedNodesManager) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tenant-dedicated-nodes").
+		For(&capsulev1beta2.Tenant{}).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.enqueueFromNode), builder.WithPredicates(dedicatedNodesPredicate())).
+		Complete(r)
+}
+
+// dedicatedNodesPredicate filters the updates of the nodes changing their labels, annotations, or taints.
+func dedicatedNodesPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, oldOk := e.ObjectOld.(*corev1.Node)
+			newNode, newOk := e.ObjectNew.(*corev1.Node)
+
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldNode.GetLabels(), newNode.GetLabels()) ||
+				!equality.Semantic.DeepEqual(oldNode.GetAnnotations(), newNode.GetAnnotations()) ||
+				!equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+		},
+	}
+}
+
+func (r *DedicatedNodesManager) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	tnt := &capsulev1beta2.Tenant{}
+	if err := r.Get(ctx, request.NamespacedName, tnt); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The nodes dedicated to the deleted Tenant are released
+		if err = r.releaseDedicatedNodes(ctx, request.Name, nil); err != nil {
+			r.Log.Error(err, "Cannot release dedicated Nodes", "Request.Name", request.Name)
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.syncDedicatedNodes(ctx, tnt); err != nil {
+		r.Log.Error(err, "Cannot sync dedicated Nodes", "Request.Name", request.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+type dedicatedNodeMetadata struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Taints []corev1.Taint    `json:"taints,omitempty"`
+}
+
+// dedicatedNodesSelector returns the selector of the nodes dedicated to the Tenant, if any:
+// an empty selector is ignored, since it would select all the nodes.
+func dedicatedNodesSelector(tnt *capsulev1beta2.Tenant) (labels.Selector, error) {
+	if tnt.Spec.SchedulingOptions == nil || tnt.Spec.SchedulingOptions.DedicatedNodes == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	selector, err := tnt.Spec.SchedulingOptions.DedicatedNodes.NodeSelector()
+	if err != nil {
+		return nil, fmt.Errorf("invalid dedicated nodes selector: %w", err)
+	}
+
+	if selector.Empty() {
+		return nil, nil //nolint:nilnil
+	}
+
+	return selector, nil
+}
+
+// syncDedicatedNodes labels the nodes dedicated to the Tenant with its node selector, and applies the taints:
+// the labels and taints added by Capsule are removed from the nodes that are no longer dedicated to the Tenant.
+// The nodes already dedicated to another Tenant are skipped.
+func (r *DedicatedNodesManager) syncDedicatedNodes(ctx context.Context, tnt *capsulev1beta2.Tenant) error {
+	selector, err := dedicatedNodesSelector(tnt)
+	if err != nil {
+		// The selector is validated by the Tenant webhook: the dedicated nodes are released rather than blocking the reconciliation.
+		r.Log.Error(err, "Cannot select the dedicated Nodes")
+	}
+
+	desired := sets.New[string]()
+
+	if selector != nil {
+		nodes := &corev1.NodeList{}
+		if err = r.List(ctx, nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return err
+		}
+
+		for _, item := range nodes.Items {
+			if owner, ok := item.GetLabels()[dedicatedNodeLabel]; ok && owner != tnt.GetName() {
+				r.Log.Info("Skipping Node already dedicated to another Tenant", "node", item.GetName(), "tenant", owner)
+
+				continue
+			}
+
+			desired.Insert(item.GetName())
+		}
+	}
+
+	for _, name := range sets.List(desired) {
+		if err = r.updateNode(ctx, name, func(node *corev1.Node) {
+			dedicateNode(node, tnt.GetName(), tnt.Spec.NodeSelector, tnt.Spec.SchedulingOptions.DedicatedNodes.Taints)
+		}); err != nil {
+			return fmt.Errorf("cannot dedicate node %s: %w", name, err)
+		}
+	}
+
+	return r.releaseDedicatedNodes(ctx, tnt.GetName(), desired)
+}
+
+// releaseDedicatedNodes removes the labels and taints added by Capsule from the nodes dedicated to the given Tenant,
+// except the desired ones.
+func (r *DedicatedNodesManager) releaseDedicatedNodes(ctx context.Context, tenant string, desired sets.Set[string]) error {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels{dedicatedNodeLabel: tenant}); err != nil {
+		return err
+	}
+
+	for _, item := range nodes.Items {
+		if desired.Has(item.GetName()) {
+			continue
+		}
+
+		if err := r.updateNode(ctx, item.GetName(), releaseNode); err != nil {
+			return fmt.Errorf("cannot release node %s: %w", item.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// updateNode applies the given mutation to the node, updating it only if changed.
+func (r *DedicatedNodesManager) updateNode(ctx context.Context, name string, mutate func(node *corev1.Node)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+			return err
+		}
+
+		original := node.DeepCopy()
+
+		mutate(node)
+
+		if equality.Semantic.DeepEqual(original, node) {
+			return nil
+		}
+
+		return r.Update(ctx, node)
+	})
+}
+
+// dedicateNode ensures the given labels and taints on the node, keeping track of the ones added by Capsule:
+// the ones previously added, and no longer desired, are removed.
+func dedicateNode(node *corev1.Node, tenant string, nodeLabels map[string]string, taints []corev1.Taint) {
+	applied := nodeMetadata(node)
+	releaseMetadata(node, applied, nodeLabels, taints)
+
+	added := dedicatedNodeMetadata{Labels: map[string]string{}}
+
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+
+	for key, value := range nodeLabels {
+		// Labels already set on the node, and not by Capsule, must not be removed upon release.
+		if current, ok := node.Labels[key]; ok && current == value && applied.Labels[key] != value {
+			continue
+		}
+
+		node.Labels[key], added.Labels[key] = value, value
+	}
+
+	for _, taint := range taints {
+		found := false
+
+		for i := range node.Spec.Taints {
+			if !node.Spec.Taints[i].MatchTaint(&taint) {
+				continue
+			}
+
+			found = true
+
+			node.Spec.Taints[i].Value = taint.Value
+		}
+
+		if !found || hasTaint(applied.Taints, taint) {
+			added.Taints = append(added.Taints, taint)
+		}
+
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+		}
+	}
+
+	node.Labels[dedicatedNodeLabel] = tenant
+
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	//nolint:errchkjson
+	metadata, _ := json.Marshal(added)
+	node.Annotations[dedicatedNodeAnnotation] = string(metadata)
+}
+
+// releaseNode removes the labels and taints added by Capsule to a node no longer dedicated to a Tenant.
+func releaseNode(node *corev1.Node) {
+	releaseMetadata(node, nodeMetadata(node), nil, nil)
+
+	delete(node.Labels, dedicatedNodeLabel)
+	delete(node.Annotations, dedicatedNodeAnnotation)
+}
+
+// releaseMetadata removes the applied labels and taints from the node, unless desired.
+func releaseMetadata(node *corev1.Node, applied dedicatedNodeMetadata, nodeLabels map[string]string, taints []corev1.Taint) {
+	for key, value := range applied.Labels {
+		if desired, ok := nodeLabels[key]; ok && desired == value {
+			continue
+		}
+
+		if node.Labels[key] == value {
+			delete(node.Labels, key)
+		}
+	}
+
+	for _, taint := range applied.Taints {
+		if hasTaint(taints, taint) {
+			continue
+		}
+
+		nodeTaints := make([]corev1.Taint, 0, len(node.Spec.Taints))
+
+		for i := range node.Spec.Taints {
+			if node.Spec.Taints[i].MatchTaint(&taint) {
+				continue
+			}
+
+			nodeTaints = append(nodeTaints, node.Spec.Taints[i])
+		}
+
+		node.Spec.Taints = nodeTaints
+	}
+}
+
+// nodeMetadata returns the labels and taints added by Capsule to the node.
+func nodeMetadata(node *corev1.Node) (metadata dedicatedNodeMetadata) {
+	if value, ok := node.GetAnnotations()[dedicatedNodeAnnotation]; ok {
+		// A corrupted annotation is ignored, and overwritten.
+		_ = json.Unmarshal([]byte(value), &metadata)
+	}
+
+	return metadata
+}
+
+func hasTaint(taints []corev1.Taint, taint corev1.Taint) bool {
+	for i := range taints {
+		if taints[i].MatchTaint(&taint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enqueueFromNode enqueues the Tenants the given node is dedicated to, or has been dedicated to.
+func (r *DedicatedNodesManager) enqueueFromNode(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	tenants := &capsulev1beta2.TenantList{}
+	if err := r.List(ctx, tenants); err != nil {
+		r.Log.Error(err, "Cannot list Tenants for the dedicated nodes")
+
+		return nil
+	}
+
+	owner := obj.GetLabels()[dedicatedNodeLabel]
+
+	for i := range tenants.Items {
+		selector, err := dedicatedNodesSelector(&tenants.Items[i])
+		if err != nil {
+			continue
+		}
+
+		if tenants.Items[i].GetName() != owner && (selector == nil || !selector.Matches(labels.Set(obj.GetLabels()))) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tenants.Items[i].GetName()}})
+	}
+
+	return requests
+}
@@ -0,0 +1,64 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestDedicateAndReleaseNode(t *testing.T) {
+	gpu := corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	dedicated := corev1.Taint{Key: "dedicated", Value: "oil", Effect: corev1.TaintEffectNoSchedule}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"pool": "oil", "zone": "a"}},
+		Spec:       corev1.NodeSpec{Taints: []corev1.Taint{gpu}},
+	}
+
+	dedicateNode(node, "oil", map[string]string{"pool": "oil", "tenant": "oil"}, []corev1.Taint{dedicated})
+
+	assert.Equal(t, map[string]string{"pool": "oil", "zone": "a", "tenant": "oil", dedicatedNodeLabel: "oil"}, node.Labels)
+	assert.ElementsMatch(t, []corev1.Taint{gpu, dedicated}, node.Spec.Taints)
+	assert.Equal(t, map[string]string{"tenant": "oil"}, nodeMetadata(node).Labels)
+
+	// Dedicating the node again is idempotent, and the labels no longer desired are removed.
+	dedicateNode(node, "oil", map[string]string{"pool": "oil"}, []corev1.Taint{dedicated})
+
+	assert.Equal(t, map[string]string{"pool": "oil", "zone": "a", dedicatedNodeLabel: "oil"}, node.Labels)
+	assert.ElementsMatch(t, []corev1.Taint{gpu, dedicated}, node.Spec.Taints)
+
+	// Upon release, only the labels and taints added by Capsule are removed.
+	releaseNode(node)
+
+	assert.Equal(t, map[string]string{"pool": "oil", "zone": "a"}, node.Labels)
+	assert.Equal(t, []corev1.Taint{gpu}, node.Spec.Taints)
+	assert.NotContains(t, node.Annotations, dedicatedNodeAnnotation)
+}
+
+func TestDedicatedNodesPredicate(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"pool": "oil"}},
+	}
+
+	heartbeat := node.DeepCopy()
+	heartbeat.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	assert.False(t, dedicatedNodesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: heartbeat}))
+
+	labeled := node.DeepCopy()
+	labeled.Labels["pool"] = "gas"
+	assert.True(t, dedicatedNodesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: labeled}))
+
+	annotated := node.DeepCopy()
+	annotated.Annotations = map[string]string{"team": "oil"}
+	assert.True(t, dedicatedNodesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: annotated}))
+
+	tainted := node.DeepCopy()
+	tainted.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "oil", Effect: corev1.TaintEffectNoSchedule}}
+	assert.True(t, dedicatedNodesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: tainted}))
+}
@@ -0,0 +1,81 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// reconcileStatus retrieves the Tenant of the request, ignoring the deleted ones, and reports the given section of its status.
+func reconcileStatus(ctx context.Context, c client.Client, log logr.Logger, request ctrl.Request, section string, sync func(context.Context, *capsulev1beta2.Tenant) error) (ctrl.Result, error) {
+	tnt := &capsulev1beta2.Tenant{}
+	if err := c.Get(ctx, request.NamespacedName, tnt); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if err := sync(ctx, tnt); err != nil {
+		log.Error(err, "Cannot sync "+section, "Request.Name", request.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// updateStatus sets the given value to the status field of the Tenant, retrieving it again upon conflicts:
+// the status is updated only when the value has changed.
+func updateStatus[T any](ctx context.Context, c client.Client, name string, field func(*capsulev1beta2.TenantStatus) *T, value T) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.Tenant{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, found); err != nil {
+			return err
+		}
+
+		current := field(&found.Status)
+		if reflect.DeepEqual(*current, value) {
+			return nil
+		}
+
+		*current = value
+
+		return c.Status().Update(ctx, found, &client.SubResourceUpdateOptions{})
+	})
+}
+
+// enqueueNamespaceTenant returns a function enqueuing the Tenant owning the Namespace of the given object, if any,
+// and if it's selected by the given filter.
+func enqueueNamespaceTenant(c client.Client, log logr.Logger, filter func(*capsulev1beta2.Tenant) bool) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		tenants := &capsulev1beta2.TenantList{}
+		if err := c.List(ctx, tenants, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(".status.namespaces", obj.GetNamespace())}); err != nil {
+			log.Error(err, "Cannot list Tenants for the Namespace", "namespace", obj.GetNamespace())
+
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(tenants.Items))
+
+		for i := range tenants.Items {
+			if !filter(&tenants.Items[i]) {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tenants.Items[i].GetName()}})
+		}
+
+		return requests
+	}
+}
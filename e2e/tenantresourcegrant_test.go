@@ -0,0 +1,186 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+var _ = Describe("Creating a TenantResourceGrant object", func() {
+	provider := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "grant-provider",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "grant-provider-user",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	consumer := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "grant-consumer",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "grant-consumer-user",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	shared := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-ca",
+			Namespace: "grant-provider-system",
+			Labels: map[string]string{
+				"shared": "ca",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	grant := func(namespace string) *capsulev1beta2.TenantResourceGrant {
+		return &capsulev1beta2.TenantResourceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shared-ca",
+				Namespace: namespace,
+			},
+			Spec: capsulev1beta2.TenantResourceGrantSpec{
+				Tenants: []string{consumer.GetName()},
+				Resources: []capsulev1beta2.GrantedResource{
+					{
+						Kind:       "Secret",
+						APIVersion: "v1",
+						Selector: &metav1.LabelSelector{
+							MatchLabels: shared.GetLabels(),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tr := &capsulev1beta2.TenantResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-ca",
+			Namespace: "grant-consumer-system",
+		},
+		Spec: capsulev1beta2.TenantResourceSpec{
+			ResyncPeriod: metav1.Duration{Duration: time.Minute},
+			Resources: []capsulev1beta2.ResourceSpec{
+				{
+					NamespacedItems: []capsulev1beta2.ObjectReference{
+						{
+							ObjectReferenceAbstract: capsulev1beta2.ObjectReferenceAbstract{
+								Kind:       "Secret",
+								Namespace:  shared.GetNamespace(),
+								APIVersion: "v1",
+							},
+							Selector: metav1.LabelSelector{
+								MatchLabels: shared.GetLabels(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// ownerCRClient returns a controller-runtime client impersonating the given Tenant owner, to manage Capsule objects.
+	ownerCRClient := func(owner capsulev1beta2.OwnerSpec) client.Client {
+		c, err := config.GetConfig()
+		Expect(err).ToNot(HaveOccurred())
+		c.Impersonate.Groups = []string{"projectcapsule.dev", owner.Name}
+		c.Impersonate.UserName = owner.Name
+
+		cl, err := client.New(c, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).ToNot(HaveOccurred())
+
+		return cl
+	}
+
+	JustBeforeEach(func() {
+		for _, tnt := range []*capsulev1beta2.Tenant{provider, consumer} {
+			EventuallyCreation(func() error {
+				tnt.ResourceVersion = ""
+
+				return k8sClient.Create(context.TODO(), tnt)
+			}).Should(Succeed())
+		}
+	})
+
+	JustAfterEach(func() {
+		for _, tnt := range []*capsulev1beta2.Tenant{provider, consumer} {
+			_ = k8sClient.Delete(context.TODO(), tnt)
+		}
+	})
+
+	It("should be allowed in the Namespaces of the granting Tenant only", func() {
+		By("creating the Tenant Namespaces", func() {
+			NamespaceCreation(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "grant-provider-system"}}, provider.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+			NamespaceCreation(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "grant-consumer-system"}}, consumer.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		})
+
+		By("denying the grant in a Namespace not belonging to any Tenant", func() {
+			Expect(k8sClient.Create(context.TODO(), grant("default"))).ShouldNot(Succeed())
+		})
+
+		By("denying the grant to the owning Tenant", func() {
+			g := grant("grant-provider-system")
+			g.Spec.Tenants = []string{provider.GetName()}
+
+			Expect(ownerCRClient(provider.Spec.Owners[0]).Create(context.TODO(), g)).ShouldNot(Succeed())
+		})
+
+		By("denying the grant in a Namespace of another Tenant", func() {
+			Expect(ownerCRClient(consumer.Spec.Owners[0]).Create(context.TODO(), grant("grant-provider-system"))).ShouldNot(Succeed())
+		})
+
+		By("replicating the granted objects", func() {
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.TODO(), shared)
+			}).Should(Succeed())
+
+			EventuallyCreation(func() error {
+				return ownerCRClient(provider.Spec.Owners[0]).Create(context.TODO(), grant("grant-provider-system"))
+			}).Should(Succeed())
+
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.TODO(), tr)
+			}).Should(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(), types.NamespacedName{Name: shared.GetName(), Namespace: "grant-consumer-system"}, &corev1.Secret{})
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+
+		By("pruning the replicated objects upon the grant revocation", func() {
+			Expect(ownerCRClient(provider.Spec.Owners[0]).Delete(context.TODO(), grant("grant-provider-system"))).Should(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(), types.NamespacedName{Name: shared.GetName(), Namespace: "grant-consumer-system"}, &corev1.Secret{})
+			}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+		})
+	})
+})
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -41,8 +42,10 @@ import (
 	tlscontroller "github.com/projectcapsule/capsule/controllers/tls"
 	"github.com/projectcapsule/capsule/pkg/configuration"
 	"github.com/projectcapsule/capsule/pkg/indexer"
+	indexeringress "github.com/projectcapsule/capsule/pkg/indexer/ingress"
 	"github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/defaults"
+	"github.com/projectcapsule/capsule/pkg/webhook/gateway"
 	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
 	namespacewebhook "github.com/projectcapsule/capsule/pkg/webhook/namespace"
 	"github.com/projectcapsule/capsule/pkg/webhook/networkpolicy"
@@ -55,6 +58,7 @@ import (
 	"github.com/projectcapsule/capsule/pkg/webhook/tenant"
 	tntresource "github.com/projectcapsule/capsule/pkg/webhook/tenantresource"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+	"github.com/projectcapsule/capsule/pkg/webhook/workload"
 )
 
 var (
@@ -207,6 +211,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&tenantcontroller.ExternalIPsManager{
+		Client: manager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TenantExternalIPs"),
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantExternalIPs")
+		os.Exit(1)
+	}
+
+	if err = (&tenantcontroller.DedicatedNodesManager{
+		Client: manager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TenantDedicatedNodes"),
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantDedicatedNodes")
+		os.Exit(1)
+	}
+
+	if err = (&tenantcontroller.HostnameClaimsManager{
+		Client: manager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TenantHostnameClaims"),
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantHostnameClaims")
+		os.Exit(1)
+	}
+
 	if err = (&capsulev1beta1.Tenant{}).SetupWebhookWithManager(manager); err != nil {
 		setupLog.Error(err, "unable to create conversion webhook", "webhook", "capsulev1beta1.Tenant")
 		os.Exit(1)
@@ -224,23 +252,41 @@ func main() {
 		os.Exit(1)
 	}
 
+	// shared across the Pod and workload routes, retaining the signature verification cache
+	imageVerification := pod.ImageVerification()
+
 	// webhooks: the order matters, don't change it and just append
 	webhooksList := append(
 		make([]webhook.Webhook, 0),
-		route.Pod(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.PriorityClass(), pod.RuntimeClass()),
+		route.Pod(pod.ImagePullPolicy(cfg), pod.ContainerRegistry(), pod.ImageReference(), imageVerification, pod.PriorityClass(), pod.RuntimeClass(), pod.SecurityConstraints(), pod.Scheduling()),
 		route.Namespace(utils.InCapsuleGroups(cfg, namespacewebhook.PatchHandler(capsuleUserName), namespacewebhook.QuotaHandler(), namespacewebhook.FreezeHandler(cfg), namespacewebhook.PrefixHandler(cfg), namespacewebhook.UserMetadataHandler())),
-		route.Ingress(ingress.Class(cfg, kubeVersion), ingress.Hostnames(cfg), ingress.Collision(cfg), ingress.Wildcard()),
+		route.Ingress(ingress.Class(cfg, kubeVersion), ingress.Hostnames(cfg), ingress.Collision(cfg), ingress.Wildcard(), ingress.Claims(), ingress.Annotations(), ingress.TLS(), ingress.Paths()),
 		route.PVC(pvc.Validating(), pvc.PersistentVolumeReuse()),
 		route.Service(service.Handler()),
 		route.TenantResourceObjects(utils.InCapsuleGroups(cfg, tntresource.WriteOpsHandler())),
 		route.NetworkPolicy(utils.InCapsuleGroups(cfg, networkpolicy.Handler())),
-		route.Tenant(tenant.NameHandler(), tenant.RoleBindingRegexHandler(), tenant.IngressClassRegexHandler(), tenant.StorageClassRegexHandler(), tenant.ContainerRegistryRegexHandler(), tenant.HostnameRegexHandler(), tenant.FreezedEmitter(), tenant.ServiceAccountNameHandler(), tenant.ForbiddenAnnotationsRegexHandler(), tenant.ProtectedHandler(), tenant.MetaHandler()),
+		route.Tenant(tenant.NameHandler(), tenant.RoleBindingRegexHandler(), tenant.IngressClassRegexHandler(), tenant.StorageClassRegexHandler(), tenant.ContainerRegistryRegexHandler(), tenant.ImageVerificationHandler(), tenant.HostnameRegexHandler(), tenant.HostnameClaimsHandler(), tenant.PathPrefixesHandler(), tenant.IngressDefaultsHandler(), tenant.ExternalServiceIPsHandler(), tenant.SchedulingOptionsHandler(), tenant.ImagePullPolicyRulesHandler(), tenant.FreezedEmitter(), tenant.ServiceAccountNameHandler(), tenant.ForbiddenAnnotationsRegexHandler(), tenant.ProtectedHandler(), tenant.MetaHandler()),
 		route.OwnerReference(utils.InCapsuleGroups(cfg, ownerreference.Handler(cfg,capsuleUserName))),
 		route.Cordoning(tenant.CordoningHandler(cfg), tenant.ResourceCounterHandler(manager.GetClient())),
 		route.Node(utils.InCapsuleGroups(cfg, node.UserMetadataHandler(cfg, kubeVersion))),
 		route.Defaults(defaults.Handler(cfg, kubeVersion)),
+		route.Workload(pod.ImagePullPolicy(cfg), pod.ContainerRegistry(), pod.ImageReference(), imageVerification, pod.PriorityClass(), pod.RuntimeClass(), pod.SecurityConstraints(), pod.Scheduling()),
+		route.WorkloadLimits(workload.Limits()),
+		route.HorizontalPodAutoscaler(workload.Limits()),
+		route.Gateway(gateway.Class(), gateway.Hostnames(), gateway.Collision(), gateway.ParentGateways(), gateway.Claims()),
+		route.TenantResourceGrants(tntresource.GrantsHandler()),
 	)
 
+	// OpenShift Routes are enforced only when their API is served, as on OpenShift clusters
+	if _, err = manager.GetRESTMapper().RESTMapping(indexeringress.OpenShiftRouteGroupVersionKind.GroupKind(), indexeringress.OpenShiftRouteGroupVersionKind.Version); err == nil {
+		webhooksList = append(webhooksList, route.OpenShiftRoute(ingress.OpenShiftRouteHandler(ingress.Hostnames(cfg), ingress.Collision(cfg), ingress.Wildcard(), ingress.Claims(), ingress.Paths())))
+	} else if !meta.IsNoMatchError(err) {
+		setupLog.Error(err, "unable to discover the OpenShift Route API")
+		os.Exit(1)
+	} else {
+		setupLog.Info("Disabling OpenShift Route webhook as the route.openshift.io API is not available")
+	}
+
 	nodeWebhookSupported, _ := utils.NodeWebhookSupported(kubeVersion)
 	if !nodeWebhookSupported {
 		setupLog.Info("Disabling node labels verification webhook as current Kubernetes version doesn't have fix for CVE-2021-25735")
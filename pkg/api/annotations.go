@@ -9,4 +9,6 @@ const (
 	ForbiddenNamespaceAnnotationsAnnotation       = "capsule.clastix.io/forbidden-namespace-annotations"
 	ForbiddenNamespaceAnnotationsRegexpAnnotation = "capsule.clastix.io/forbidden-namespace-annotations-regexp"
 	ProtectedTenantAnnotation                     = "capsule.clastix.io/protected"
+	OriginalImagesAnnotation                      = "capsule.clastix.io/original-images"
+	AssignExternalIPAnnotation                    = "capsule.clastix.io/assign-external-ip"
 )
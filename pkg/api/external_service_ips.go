@@ -3,11 +3,147 @@
 
 package api
 
-// +kubebuilder:validation:Pattern="^([0-9]{1,3}.){3}[0-9]{1,3}(/([0-9]|[1-2][0-9]|3[0-2]))?$"
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+// AllowedIP is an IPv4 or IPv6 address, or CIDR: the values are validated upon the Tenant admission.
 type AllowedIP string
 
+// Network returns the network of the allowed IP: a single address is considered as a /32 network, or /128 for IPv6.
+func (in AllowedIP) Network() (*net.IPNet, error) {
+	return ParseNetwork(string(in))
+}
+
+// ParseNetwork parses the given IPv4 or IPv6 address, or CIDR, returning its network.
+func ParseNetwork(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("%s is not a valid IP address", value)
+		}
+
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid CIDR: %w", value, err)
+	}
+
+	return network, nil
+}
+
 // +kubebuilder:object:generate=true
 
 type ExternalServiceIPsSpec struct {
 	Allowed []AllowedIP `json:"allowed"`
+	// Assigns a free external IP, among the allowed ones, to the Services of the Tenant
+	// requesting it with the capsule.clastix.io/assign-external-ip annotation set to true.
+	// +kubebuilder:default=false
+	AutoAssign bool `json:"autoAssign,omitempty"`
+}
+
+// Contains returns true if the given IP is within the allowed ones.
+func (in *ExternalServiceIPsSpec) Contains(ip net.IP) bool {
+	for _, allowed := range in.Allowed {
+		if network, err := allowed.Network(); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Capacity returns the number of assignable addresses of the allowed networks: overlapping networks are counted once per network.
+func (in *ExternalServiceIPsSpec) Capacity() *big.Int {
+	capacity := big.NewInt(0)
+
+	for _, allowed := range in.Allowed {
+		network, err := allowed.Network()
+		if err != nil {
+			continue
+		}
+
+		ones, bits := network.Mask.Size()
+
+		capacity.Add(capacity, new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)))
+
+		if hasReservedAddresses(network) {
+			capacity.Sub(capacity, big.NewInt(2))
+		}
+	}
+
+	return capacity
+}
+
+// NextIP returns the first assignable address, among the allowed ones, for which the given function returns false:
+// the network and broadcast addresses of the IPv4 networks are skipped, unless these are /31 or /32 ones.
+func (in *ExternalServiceIPsSpec) NextIP(used func(ip net.IP) bool) (net.IP, bool) {
+	for _, allowed := range in.Allowed {
+		network, err := allowed.Network()
+		if err != nil {
+			continue
+		}
+
+		start, broadcast, reserved := network.IP.Mask(network.Mask), broadcastIP(network), hasReservedAddresses(network)
+
+		for ip := start; ; {
+			if !(reserved && (ip.Equal(start) || ip.Equal(broadcast))) && !used(ip) {
+				return ip, true
+			}
+			// Stopping at the end of the network, or when wrapping around at the end of the address space.
+			if ip = nextIP(ip); !network.Contains(ip) || ip.Equal(start) {
+				break
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// nextIP returns the address following the given one, wrapping around at the end of the address space.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
+
+// hasReservedAddresses returns true for the IPv4 networks having network and broadcast addresses, such as /30 or larger ones.
+func hasReservedAddresses(network *net.IPNet) bool {
+	ones, bits := network.Mask.Size()
+
+	return bits == 8*net.IPv4len && ones < 31
+}
+
+// broadcastIP returns the last address of the given network.
+func broadcastIP(network *net.IPNet) net.IP {
+	ip := network.IP.Mask(network.Mask)
+
+	broadcast := make(net.IP, len(ip))
+
+	for i := range ip {
+		broadcast[i] = ip[i] | ^network.Mask[i]
+	}
+
+	return broadcast
 }
@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetwork(t *testing.T) {
+	for value, expected := range map[string]string{
+		"10.0.0.1":         "10.0.0.1/32",
+		"10.0.0.0/24":      "10.0.0.0/24",
+		"10.0.0.12/24":     "10.0.0.0/24",
+		"2001:db8::1":      "2001:db8::1/128",
+		"2001:db8::/64":    "2001:db8::/64",
+		" 192.168.0.0/16 ": "192.168.0.0/16",
+	} {
+		network, err := ParseNetwork(value)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, expected, network.String(), value)
+		}
+	}
+
+	for _, value := range []string{"", "10.0.0", "10.0.0.0/33", "2001:db8::/129", "acme.com"} {
+		_, err := ParseNetwork(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestExternalServiceIPsPool(t *testing.T) {
+	pool := &ExternalServiceIPsSpec{Allowed: []AllowedIP{"10.0.0.0/30", "2001:db8::10"}}
+
+	assert.True(t, pool.Contains(net.ParseIP("10.0.0.3")))
+	assert.False(t, pool.Contains(net.ParseIP("10.0.0.4")))
+	assert.True(t, pool.Contains(net.ParseIP("2001:db8::10")))
+	assert.False(t, pool.Contains(net.ParseIP("2001:db8::11")))
+	// The network and broadcast addresses of the IPv4 networks are not assignable.
+	assert.Equal(t, "3", pool.Capacity().String())
+
+	used := map[string]bool{"10.0.0.1": true}
+
+	ip, ok := pool.NextIP(func(ip net.IP) bool { return used[ip.String()] })
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.2", ip.String())
+
+	used["10.0.0.2"] = true
+
+	ip, ok = pool.NextIP(func(ip net.IP) bool { return used[ip.String()] })
+	assert.True(t, ok)
+	assert.Equal(t, "2001:db8::10", ip.String())
+
+	used["2001:db8::10"] = true
+
+	_, ok = pool.NextIP(func(ip net.IP) bool { return used[ip.String()] })
+	assert.False(t, ok)
+	// The /31 and /32 networks have no network and broadcast addresses.
+	pool = &ExternalServiceIPsSpec{Allowed: []AllowedIP{"10.0.0.0/31", "10.0.1.0"}}
+	assert.Equal(t, "3", pool.Capacity().String())
+
+	ip, ok = pool.NextIP(func(net.IP) bool { return false })
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.0", ip.String())
+	// The networks at the end of the address space are not wrapping around.
+	_, ok = (&ExternalServiceIPsSpec{Allowed: []AllowedIP{"255.255.255.254/31", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"}}).NextIP(func(net.IP) bool { return true })
+	assert.False(t, ok)
+}
@@ -0,0 +1,96 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"strings"
+)
+
+const (
+	hostnameIndexPrefix = "host:"
+	wildcardIndexPrefix = "wildcard:"
+)
+
+// HostnameClaim is either an exact hostname, such as app.acme.com,
+// or a domain suffix starting with a wildcard, such as *.acme.com, covering all of its subdomains.
+// +kubebuilder:validation:Pattern=`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+type HostnameClaim string
+
+// Overlaps returns true if any hostname is covered by both the claims:
+// hostnames in use, including wildcard ones, can be checked as claims too.
+func (in HostnameClaim) Overlaps(other HostnameClaim) bool {
+	a, aWildcard := in.domain()
+	b, bWildcard := other.domain()
+
+	switch {
+	case !aWildcard && !bWildcard:
+		return a == b
+	case aWildcard && !bWildcard:
+		return strings.HasSuffix(b, "."+a)
+	case !aWildcard && bWildcard:
+		return strings.HasSuffix(a, "."+b)
+	default:
+		return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+	}
+}
+
+// domain returns the hostname, or the domain suffix of wildcard claims, lower cased.
+func (in HostnameClaim) domain() (string, bool) {
+	value := strings.ToLower(string(in))
+
+	if strings.HasPrefix(value, "*.") {
+		return strings.TrimPrefix(value, "*."), true
+	}
+
+	return value, false
+}
+
+// IndexValues returns the values indexing the claim, allowing to look up the overlapping ones with OverlappingIndexValues:
+// the claim itself, and the wildcards covering it, such as *.acme.com and *.com for www.acme.com.
+func (in HostnameClaim) IndexValues() []string {
+	domain, _ := in.domain()
+
+	values := []string{hostnameIndexPrefix + strings.ToLower(string(in))}
+
+	for _, parent := range parentDomains(domain) {
+		values = append(values, wildcardIndexPrefix+parent)
+	}
+
+	return values
+}
+
+// OverlappingIndexValues returns the index values of the claims overlapping the given one:
+// the same claim, the wildcards covering it, or the claims covered by it, when it's a wildcard.
+func (in HostnameClaim) OverlappingIndexValues() []string {
+	if in == "" {
+		return nil
+	}
+
+	domain, wildcard := in.domain()
+
+	values := []string{hostnameIndexPrefix + strings.ToLower(string(in))}
+
+	if wildcard {
+		values = append(values, wildcardIndexPrefix+domain)
+	}
+
+	for _, parent := range parentDomains(domain) {
+		values = append(values, hostnameIndexPrefix+"*."+parent)
+	}
+
+	return values
+}
+
+// parentDomains returns the parent domains of the given one, such as acme.com and com for www.acme.com.
+func parentDomains(domain string) (parents []string) {
+	for {
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || parent == "" {
+			return parents
+		}
+
+		parents = append(parents, parent)
+		domain = parent
+	}
+}
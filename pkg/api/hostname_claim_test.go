@@ -0,0 +1,34 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestHostnameClaimOverlaps(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     HostnameClaim
+		overlaps bool
+	}{
+		{"app.acme.com", "app.acme.com", true},
+		{"app.acme.com", "api.acme.com", false},
+		{"*.acme.com", "app.acme.com", true},
+		{"*.acme.com", "v1.api.acme.com", true},
+		{"*.acme.com", "acme.com", false},
+		{"*.acme.com", "notacme.com", false},
+		{"*.acme.com", "*.api.acme.com", true},
+		{"*.acme.com", "*.acme.org", false},
+		{"App.Acme.com", "app.acme.com", true},
+	} {
+		assert.Equal(t, tc.overlaps, tc.a.Overlaps(tc.b), "%s and %s", tc.a, tc.b)
+		assert.Equal(t, tc.overlaps, tc.b.Overlaps(tc.a), "%s and %s", tc.b, tc.a)
+		// The index lookup is agreeing with the overlapping.
+		assert.Equal(t, tc.overlaps, sets.New(tc.a.OverlappingIndexValues()...).HasAny(tc.b.IndexValues()...), "%s index values of %s", tc.a, tc.b)
+		assert.Equal(t, tc.overlaps, sets.New(tc.b.OverlappingIndexValues()...).HasAny(tc.a.IndexValues()...), "%s index values of %s", tc.b, tc.a)
+	}
+}
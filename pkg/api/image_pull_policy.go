@@ -9,3 +9,16 @@ type ImagePullPolicySpec string
 func (i ImagePullPolicySpec) String() string {
 	return string(i)
 }
+
+// +kubebuilder:object:generate=true
+
+type ImagePullPolicyRule struct {
+	// Specifies the container images the rule applies to: either a registry, such as quay.io, or a repository pattern,
+	// such as registry.corp/team-a/*, where a trailing wildcard matches all the repositories with the given prefix.
+	Pattern string `json:"pattern"`
+	// Specifies the pull policies allowed for the matching container images.
+	// +kubebuilder:validation:MinItems=1
+	AllowedPolicies []ImagePullPolicySpec `json:"allowedPolicies"`
+	// Pull policy assigned to the matching containers using a policy not allowed by the rule, rather than rejecting them. Optional.
+	Default ImagePullPolicySpec `json:"default,omitempty"`
+}
@@ -0,0 +1,21 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+// +kubebuilder:object:generate=true
+
+type ImageReferenceSpec struct {
+	// Forbids container images using the latest tag, or not specifying any tag, nor digest.
+	// +kubebuilder:default=false
+	ForbidLatestTag bool `json:"forbidLatestTag,omitempty"`
+	// Forbids container images not specifying any tag, nor digest.
+	// +kubebuilder:default=false
+	ForbidUntagged bool `json:"forbidUntagged,omitempty"`
+	// Specifies the registries whose container images must be referenced by digest.
+	DigestRequiredRegistries *AllowedListSpec `json:"digestRequiredRegistries,omitempty"`
+	// Specifies the repositories allowed within a registry, such as registry.corp/team-a/*:
+	// a trailing wildcard allows all the repositories with the given prefix, otherwise an exact match is required.
+	// Container images hosted on registries not listed here are not restricted.
+	AllowedRepositories []string `json:"allowedRepositories,omitempty"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// +kubebuilder:object:generate=true
+
+type ImageVerificationSpec struct {
+	// Specifies the container images the policy applies to, such as registry.corp/team-a/*:
+	// a trailing wildcard matches all the repositories with the given prefix, otherwise an exact match is required.
+	Pattern string `json:"pattern"`
+	// List of trusted PEM encoded public keys, either ECDSA or ed25519:
+	// container images matching the pattern must have a valid signature from at least one of them.
+	// +kubebuilder:validation:MinItems=1
+	PublicKeys []string `json:"publicKeys"`
+}
+
+// ParsePublicKeys returns the trusted public keys, failing if any of them is not a PEM encoded ECDSA or ed25519 key.
+func (in ImageVerificationSpec) ParsePublicKeys() ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(in.PublicKeys))
+
+	for i, raw := range in.PublicKeys {
+		block, _ := pem.Decode([]byte(raw))
+		if block == nil {
+			return nil, fmt.Errorf("public key %d for pattern %s is not PEM encoded", i, in.Pattern)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse public key %d for pattern %s: %w", i, in.Pattern, err)
+		}
+
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("public key %d for pattern %s is of unsupported type %T, only ECDSA and ed25519 are supported", i, in.Pattern, key)
+		}
+	}
+
+	return keys, nil
+}
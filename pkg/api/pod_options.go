@@ -3,9 +3,21 @@
 
 package api
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // +kubebuilder:object:generate=true
 
 type PodOptions struct {
 	// Specifies additional labels and annotations the Capsule operator places on any Pod resource in the Tenant. Optional.
 	AdditionalMetadata *AdditionalMetadataSpec `json:"additionalMetadata,omitempty"`
+	// Specifies the security constraints the Pods of the Tenant must satisfy, on top of the Pod Security Admission levels:
+	// when set, privileged containers, host namespaces, and added capabilities are forbidden unless explicitly allowed. Optional.
+	Security *PodSecuritySpec `json:"security,omitempty"`
+	// Pins the Pod Security Admission labels on the Tenant Namespaces, preventing the Tenant owners from changing them. Optional.
+	PodSecurityAdmission *PodSecurityAdmissionSpec `json:"podSecurityAdmission,omitempty"`
+	// Specifies the Secrets holding the registry credentials injected in the Pods of the Tenant, if not already referenced:
+	// the Secrets must exist in the Namespace of the Pod, otherwise its creation is denied. Optional.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
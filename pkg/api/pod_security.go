@@ -0,0 +1,106 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+type PodSecuritySpec struct {
+	// Allows containers to run in privileged mode.
+	// +kubebuilder:default=false
+	AllowPrivileged bool `json:"allowPrivileged,omitempty"`
+	// Allows Pods to use the host network namespace.
+	// +kubebuilder:default=false
+	AllowHostNetwork bool `json:"allowHostNetwork,omitempty"`
+	// Allows Pods to use the host PID namespace.
+	// +kubebuilder:default=false
+	AllowHostPID bool `json:"allowHostPID,omitempty"`
+	// Allows Pods to use the host IPC namespace.
+	// +kubebuilder:default=false
+	AllowHostIPC bool `json:"allowHostIPC,omitempty"`
+	// Specifies the capabilities the containers can add: when empty, no capability can be added.
+	AllowedCapabilities []corev1.Capability `json:"allowedCapabilities,omitempty"`
+	// Specifies the volume types the Pods can use, such as configMap, secret, emptyDir, or persistentVolumeClaim:
+	// when empty, any volume type is allowed.
+	AllowedVolumeTypes []string `json:"allowedVolumeTypes,omitempty"`
+	// Specifies the path prefixes hostPath volumes can mount: when empty, any path is allowed,
+	// use allowedVolumeTypes to forbid hostPath volumes at all.
+	AllowedHostPaths []AllowedHostPath `json:"allowedHostPaths,omitempty"`
+	// Specifies the ranges of user IDs the containers can run as: when not empty, containers must set runAsUser,
+	// either at Pod or container level, to a value contained by any of the ranges.
+	RunAsUser []IDRange `json:"runAsUser,omitempty"`
+	// Requires the containers to use a seccomp profile of type RuntimeDefault or Localhost, either at Pod or container level.
+	// +kubebuilder:default=false
+	RequireSeccompProfile bool `json:"requireSeccompProfile,omitempty"`
+	// Requires the containers to use an AppArmor profile of type RuntimeDefault or Localhost, either at Pod or container level.
+	// +kubebuilder:default=false
+	RequireAppArmorProfile bool `json:"requireAppArmorProfile,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type AllowedHostPath struct {
+	// Path prefix the hostPath volumes must match, such as /var/log: /var/log/pods is allowed, while /var/logs is not.
+	PathPrefix string `json:"pathPrefix"`
+	// Requires the volumes matching the prefix to be mounted read-only.
+	// +kubebuilder:default=false
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type IDRange struct {
+	// Minimum value, inclusive.
+	// +kubebuilder:validation:Minimum=0
+	Min int64 `json:"min"`
+	// Maximum value, inclusive.
+	// +kubebuilder:validation:Minimum=0
+	Max int64 `json:"max"`
+}
+
+// Contains returns true if the given ID is within the range.
+func (in IDRange) Contains(id int64) bool {
+	return id >= in.Min && id <= in.Max
+}
+
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityLevel string
+
+// +kubebuilder:object:generate=true
+
+type PodSecurityAdmissionSpec struct {
+	// Pod Security Standard level enforced for the Tenant Namespaces.
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+	// Pod Security Standard level audited for the Tenant Namespaces.
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+	// Pod Security Standard level triggering warnings for the Tenant Namespaces.
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+	// Version of the Pod Security Standards, such as v1.31: when not specified, the latest one is used.
+	// +kubebuilder:default=latest
+	Version string `json:"version,omitempty"`
+}
+
+// Labels returns the Pod Security Admission labels for the Namespaces.
+func (in PodSecurityAdmissionSpec) Labels() map[string]string {
+	labels := map[string]string{}
+
+	version := in.Version
+	if len(version) == 0 {
+		version = "latest"
+	}
+
+	for mode, level := range map[string]PodSecurityLevel{"enforce": in.Enforce, "audit": in.Audit, "warn": in.Warn} {
+		if len(level) == 0 {
+			continue
+		}
+
+		labels["pod-security.kubernetes.io/"+mode] = string(level)
+		labels["pod-security.kubernetes.io/"+mode+"-version"] = version
+	}
+
+	return labels
+}
@@ -0,0 +1,39 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+type PriorityClassBoundsSpec struct {
+	// Minimum value, inclusive, of the Priority Classes the Pods can use.
+	Min *int32 `json:"min,omitempty"`
+	// Maximum value, inclusive, of the Priority Classes the Pods can use.
+	Max *int32 `json:"max,omitempty"`
+	// Specifies the preemption policies the Pods can use, such as Never: when empty, any policy is allowed.
+	AllowedPreemptionPolicies []corev1.PreemptionPolicy `json:"allowedPreemptionPolicies,omitempty"`
+}
+
+// InRange returns true if the given priority value is within the bounds.
+func (in *PriorityClassBoundsSpec) InRange(value int32) bool {
+	return (in.Min == nil || value >= *in.Min) && (in.Max == nil || value <= *in.Max)
+}
+
+// AllowsPreemptionPolicy returns true if the given preemption policy is allowed.
+func (in *PriorityClassBoundsSpec) AllowsPreemptionPolicy(policy corev1.PreemptionPolicy) bool {
+	if len(in.AllowedPreemptionPolicies) == 0 {
+		return true
+	}
+
+	for _, allowed := range in.AllowedPreemptionPolicies {
+		if allowed == policy {
+			return true
+		}
+	}
+
+	return false
+}
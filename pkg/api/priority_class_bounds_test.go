@@ -0,0 +1,37 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPriorityClassBoundsSpec(t *testing.T) {
+	bounds := PriorityClassBoundsSpec{
+		Min:                       ptr.To[int32](100),
+		Max:                       ptr.To[int32](1000),
+		AllowedPreemptionPolicies: []corev1.PreemptionPolicy{corev1.PreemptNever},
+	}
+
+	for _, value := range []int32{100, 500, 1000} {
+		assert.True(t, bounds.InRange(value), value)
+	}
+
+	for _, value := range []int32{0, 99, 1001, 2000000000} {
+		assert.False(t, bounds.InRange(value), value)
+	}
+
+	assert.True(t, bounds.AllowsPreemptionPolicy(corev1.PreemptNever))
+	assert.False(t, bounds.AllowsPreemptionPolicy(corev1.PreemptLowerPriority))
+
+	unbounded := PriorityClassBoundsSpec{Max: ptr.To[int32](0)}
+
+	assert.True(t, unbounded.InRange(-100))
+	assert.False(t, unbounded.InRange(1))
+	assert.True(t, unbounded.AllowsPreemptionPolicy(corev1.PreemptLowerPriority))
+}
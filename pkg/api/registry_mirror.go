@@ -0,0 +1,38 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+// +kubebuilder:object:generate=true
+
+type RegistryMirrorSpec struct {
+	// Registry whose container images must be pulled from the mirror, such as docker.io.
+	Registry string `json:"registry"`
+	// Mirror replacing the registry, optionally including a path prefix, such as mirror.corp/dockerhub.
+	Mirror string `json:"mirror"`
+}
+
+// MirrorFor returns the mirror of the given registry, looking up the given lists in order:
+// this allows Tenant mirrors to take precedence over the cluster wide ones.
+func MirrorFor(registry string, mirrors ...[]RegistryMirrorSpec) (string, bool) {
+	for _, list := range mirrors {
+		for _, mirror := range list {
+			if NormalizeRegistry(mirror.Registry) == NormalizeRegistry(registry) {
+				return mirror.Mirror, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// NormalizeRegistry returns the canonical name of the given registry:
+// the Docker Hub aliases, such as index.docker.io, are normalized to docker.io, as the container runtimes do.
+func NormalizeRegistry(registry string) string {
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	default:
+		return registry
+	}
+}
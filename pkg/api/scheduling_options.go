@@ -0,0 +1,62 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// +kubebuilder:object:generate=true
+
+type SchedulingOptions struct {
+	// Enforces the Tenant node selector through the Capsule webhooks, rather than relying on the PodNodeSelector admission plugin:
+	// the node selector is injected into the Pods, which cannot set conflicting values.
+	// +kubebuilder:default=false
+	EnforceNodeSelector bool `json:"enforceNodeSelector,omitempty"`
+	// Node selector terms the Pods are required to match: these are merged with the required node affinity of the Pods,
+	// hence both must be satisfied.
+	RequiredNodeAffinity *corev1.NodeSelector `json:"requiredNodeAffinity,omitempty"`
+	// Tolerations injected into the Pods, such as the ones for the taints of the nodes dedicated to the Tenant.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Dedicates a pool of nodes to the Tenant: the selected nodes are labelled with the Tenant node selector, and tainted.
+	DedicatedNodes *DedicatedNodesSpec `json:"dedicatedNodes,omitempty"`
+}
+
+// IsEnforcingPlacement returns true if Pods must be scheduled by the scheduler, according to the Tenant constraints,
+// hence they cannot be bound to a node through the nodeName field.
+func (in *SchedulingOptions) IsEnforcingPlacement() bool {
+	return in != nil && (in.EnforceNodeSelector || in.RequiredNodeAffinity != nil)
+}
+
+// +kubebuilder:object:generate=true
+
+type DedicatedNodesSpec struct {
+	// Selects the nodes dedicated to the Tenant.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Taints applied to the dedicated nodes: the Tenant Pods are allowed to tolerate them with the tolerations field.
+	Taints []corev1.Taint `json:"taints,omitempty"`
+}
+
+// NodeSelector returns the selector of the dedicated nodes:
+// an empty selector is selecting all the nodes, thus it must be checked by the callers.
+func (in *DedicatedNodesSpec) NodeSelector() (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(&in.Selector)
+}
+
+// HasTaint returns true if the given taint is applied to the dedicated nodes.
+func (in *DedicatedNodesSpec) HasTaint(taint corev1.Taint) bool {
+	if in == nil {
+		return false
+	}
+
+	for i := range in.Taints {
+		if in.Taints[i].MatchTaint(&taint) && in.Taints[i].Value == taint.Value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,10 @@
 
 package api
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // +kubebuilder:object:generate=true
 
 type ServiceOptions struct {
@@ -16,4 +20,16 @@ type ServiceOptions struct {
 	ForbiddenLabels ForbiddenListSpec `json:"forbiddenLabels,omitempty"`
 	// Define the annotations that a Tenant Owner cannot set for their Service resources.
 	ForbiddenAnnotations ForbiddenListSpec `json:"forbiddenAnnotations,omitempty"`
+	// Specifies the ranges the node ports of the Services of the Tenant must be within:
+	// when set, the node ports must be specified explicitly. Optional.
+	AllowedNodePortRanges []PortRange `json:"allowedNodePortRanges,omitempty"`
+	// Specifies the external traffic policies the NodePort and LoadBalancer Services of the Tenant can use. Optional.
+	AllowedExternalTrafficPolicies []corev1.ServiceExternalTrafficPolicy `json:"allowedExternalTrafficPolicies,omitempty"`
+	// Specifies the policy of the Services with type LoadBalancer. Optional.
+	LoadBalancer *LoadBalancerPolicy `json:"loadBalancer,omitempty"`
+	// Specifies the domains the ExternalName Services of the Tenant can point to:
+	// when set, the externalName must match one of them. Optional.
+	AllowedExternalNames *AllowedListSpec `json:"allowedExternalNames,omitempty"`
+	// Specifies the domains the ExternalName Services of the Tenant cannot point to. Optional.
+	ForbiddenExternalNames ForbiddenListSpec `json:"forbiddenExternalNames,omitempty"`
 }
@@ -0,0 +1,35 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+// +kubebuilder:object:generate=true
+
+type PortRange struct {
+	// Minimum port, inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Min int32 `json:"min"`
+	// Maximum port, inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Max int32 `json:"max"`
+}
+
+// Contains returns true if the given port is within the range.
+func (in PortRange) Contains(port int32) bool {
+	return port >= in.Min && port <= in.Max
+}
+
+// +kubebuilder:object:generate=true
+
+type LoadBalancerPolicy struct {
+	// Specifies the allowed values of the loadBalancerClass: when set, the Services must specify one of them. Optional.
+	AllowedClasses []string `json:"allowedClasses,omitempty"`
+	// Specifies the CIDRs the loadBalancerSourceRanges must be within: when set,
+	// the Services must specify source ranges, and each of them must be contained in one of the allowed CIDRs. Optional.
+	AllowedSourceRanges []AllowedIP `json:"allowedSourceRanges,omitempty"`
+	// Specifies the maximum number of Services with type LoadBalancer across the Namespaces of the Tenant. Optional.
+	// +kubebuilder:validation:Minimum=0
+	MaxCount *int32 `json:"maxCount,omitempty"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+type WorkloadLimitsSpec struct {
+	// Maximum number of replicas of the Deployments and StatefulSets, including the scale subresource.
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Maximum value of the maxReplicas field of the HorizontalPodAutoscalers.
+	// +kubebuilder:validation:Minimum=1
+	MaxAutoscalerReplicas *int32 `json:"maxAutoscalerReplicas,omitempty"`
+	// Maximum parallelism of the Jobs, including the ones spawned by CronJobs.
+	// +kubebuilder:validation:Minimum=0
+	MaxJobParallelism *int32 `json:"maxJobParallelism,omitempty"`
+	// Maximum completions of the Jobs, including the ones spawned by CronJobs.
+	// +kubebuilder:validation:Minimum=0
+	MaxJobCompletions *int32 `json:"maxJobCompletions,omitempty"`
+	// Minimum interval between two consecutive runs of the CronJobs, such as 15m.
+	MinCronJobInterval *metav1.Duration `json:"minCronJobInterval,omitempty"`
+}
@@ -104,3 +104,7 @@ func (c *capsuleConfiguration) ForbiddenUserNodeAnnotations() *capsuleapi.Forbid
 
 	return &c.retrievalFn().Spec.NodeMetadata.ForbiddenAnnotations
 }
+
+func (c *capsuleConfiguration) RegistryMirrors() []capsuleapi.RegistryMirrorSpec {
+	return c.retrievalFn().Spec.RegistryMirrors
+}
@@ -27,4 +27,5 @@ type Configuration interface {
 	ExcludeUserGroups() []string
 	ForbiddenUserNodeLabels() *capsuleapi.ForbiddenListSpec
 	ForbiddenUserNodeAnnotations() *capsuleapi.ForbiddenListSpec
+	RegistryMirrors() []capsuleapi.RegistryMirrorSpec
 }
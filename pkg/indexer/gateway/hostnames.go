@@ -0,0 +1,142 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const (
+	HostnamesField = ".spec.hostnames"
+
+	GroupName     = "gateway.networking.k8s.io"
+	KindGateway   = "Gateway"
+	KindHTTPRoute = "HTTPRoute"
+	KindGRPCRoute = "GRPCRoute"
+
+	parentKeyPrefix = "parent:"
+)
+
+// GroupVersion is the version of the Gateway API resources indexed from the cache.
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
+
+// Object returns the unstructured object used to register, and look up, the index of the given Gateway API kind.
+func Object(kind string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersion.WithKind(kind))
+
+	return obj
+}
+
+// Hostnames indexes the Gateways by the hostnames of their listeners, and the Routes by their hostnames:
+// since the Gateway API wildcards are matching any subdomain, as the hostname claims do, the hostnames are indexed as claims.
+// The Routes without hostnames are indexed by their parent Gateways, allowing to find the ones inheriting the listener hostnames.
+type Hostnames struct {
+	Kind string
+}
+
+func (s Hostnames) Object() client.Object {
+	return Object(s.Kind)
+}
+
+func (s Hostnames) Field() string {
+	return HostnamesField
+}
+
+func (s Hostnames) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		obj, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			return nil
+		}
+
+		entries := sets.New[string]()
+
+		for _, hostname := range hostnames(obj) {
+			if hostname == "" {
+				continue
+			}
+
+			entries.Insert(api.HostnameClaim(hostname).IndexValues()...)
+		}
+
+		if obj.GetKind() != KindGateway && entries.Len() == 0 {
+			for _, parent := range parentGateways(obj) {
+				entries.Insert(ParentKey(parent.Namespace, parent.Name))
+			}
+		}
+
+		return sets.List(entries)
+	}
+}
+
+// OverlappingHostnameKeys returns the index values to look up the resources with a hostname overlapping the given one:
+// the same hostname, the wildcards matching it, or the hostnames matched by it, when it's a wildcard.
+func OverlappingHostnameKeys(hostname string) []string {
+	return api.HostnameClaim(hostname).OverlappingIndexValues()
+}
+
+// ParentKey returns the index value to look up the Routes without hostnames attached to the given Gateway.
+func ParentKey(namespace, name string) string {
+	return parentKeyPrefix + namespace + "/" + name
+}
+
+func hostnames(obj *unstructured.Unstructured) (hostnames []string) {
+	if obj.GetKind() != KindGateway {
+		hostnames, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "hostnames")
+
+		return hostnames
+	}
+
+	listeners, _, _ := unstructured.NestedSlice(obj.Object, "spec", "listeners")
+
+	for _, item := range listeners {
+		listener, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if hostname, _, _ := unstructured.NestedString(listener, "hostname"); hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	return hostnames
+}
+
+// parentGateways returns the Gateways the Route is attached to, defaulting to the Route Namespace.
+func parentGateways(obj *unstructured.Unstructured) (parents []client.ObjectKey) {
+	refs, _, _ := unstructured.NestedSlice(obj.Object, "spec", "parentRefs")
+
+	for _, item := range refs {
+		ref, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if group, found, _ := unstructured.NestedString(ref, "group"); found && group != GroupName {
+			continue
+		}
+
+		if kind, found, _ := unstructured.NestedString(ref, "kind"); found && kind != KindGateway {
+			continue
+		}
+
+		namespace, found, _ := unstructured.NestedString(ref, "namespace")
+		if !found {
+			namespace = obj.GetNamespace()
+		}
+
+		name, _, _ := unstructured.NestedString(ref, "name")
+
+		parents = append(parents, client.ObjectKey{Namespace: namespace, Name: name})
+	}
+
+	return parents
+}
@@ -15,8 +15,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/indexer/gateway"
 	"github.com/projectcapsule/capsule/pkg/indexer/ingress"
 	"github.com/projectcapsule/capsule/pkg/indexer/namespace"
+	"github.com/projectcapsule/capsule/pkg/indexer/service"
 	"github.com/projectcapsule/capsule/pkg/indexer/tenant"
 	"github.com/projectcapsule/capsule/pkg/indexer/tenantresource"
 	"github.com/projectcapsule/capsule/pkg/utils"
@@ -32,10 +34,18 @@ func AddToManager(ctx context.Context, log logr.Logger, mgr manager.Manager) err
 	indexers := []CustomIndexer{
 		tenant.NamespacesReference{Obj: &capsulev1beta2.Tenant{}},
 		tenant.OwnerReference{},
+		tenant.HostnameClaims{},
 		namespace.OwnerReference{},
-		ingress.HostnamePath{Obj: &extensionsv1beta1.Ingress{}},
-		ingress.HostnamePath{Obj: &networkingv1beta1.Ingress{}},
-		ingress.HostnamePath{Obj: &networkingv1.Ingress{}},
+		ingress.Hostnames{Obj: &extensionsv1beta1.Ingress{}},
+		ingress.Hostnames{Obj: &networkingv1beta1.Ingress{}},
+		ingress.Hostnames{Obj: &networkingv1.Ingress{}},
+		ingress.Hostnames{Obj: ingress.OpenShiftRoute()},
+		ingress.Hostnames{Obj: &capsulev1beta2.Tenant{}},
+		gateway.Hostnames{Kind: gateway.KindGateway},
+		gateway.Hostnames{Kind: gateway.KindHTTPRoute},
+		gateway.Hostnames{Kind: gateway.KindGRPCRoute},
+		service.Type{},
+		service.ExternalIPs{},
 		tenantresource.GlobalProcessedItems{},
 		tenantresource.LocalProcessedItems{},
 	}
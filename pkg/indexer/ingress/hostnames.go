@@ -0,0 +1,146 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"strings"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const (
+	HostnamesField = ".spec.hostnames"
+
+	hostnameKeyPrefix = "host:"
+	wildcardKeyPrefix = "wildcard:"
+)
+
+// OpenShiftRouteGroupVersionKind is the kind of the OpenShift Routes: being not part of the Capsule scheme,
+// they're indexed as unstructured objects.
+var OpenShiftRouteGroupVersionKind = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
+// OpenShiftRoute returns the object used to register the index of the OpenShift Routes.
+func OpenShiftRoute() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(OpenShiftRouteGroupVersionKind)
+
+	return obj
+}
+
+// Hostnames indexes the Ingresses, and the OpenShift Routes, by hostname: the exact hostnames are indexed
+// by the wildcard matching them too, such as *.acme.com for www.acme.com, allowing to find the overlapping ones from the cache.
+// The Tenants are indexed by the hostnames they're sharing through the allowed path prefixes.
+type Hostnames struct {
+	Obj client.Object
+}
+
+func (s Hostnames) Object() client.Object {
+	return s.Obj
+}
+
+func (s Hostnames) Field() string {
+	return HostnamesField
+}
+
+func (s Hostnames) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		entries := sets.New[string]()
+
+		for _, hostname := range hostnames(object) {
+			entries.Insert(hostnameKey(hostname))
+
+			if wildcard, ok := matchingWildcard(hostname); ok {
+				entries.Insert(wildcardKey(wildcard))
+			}
+		}
+
+		return sets.List(entries)
+	}
+}
+
+// OverlappingHostnameKeys returns the index values to look up the objects with a hostname overlapping the given one:
+// the same hostname, the wildcard matching it, or the hostnames matched by it, when it's a wildcard.
+func OverlappingHostnameKeys(hostname string) []string {
+	keys := []string{hostnameKey(hostname)}
+
+	if isWildcard(hostname) {
+		return append(keys, wildcardKey(hostname))
+	}
+
+	if wildcard, ok := matchingWildcard(hostname); ok {
+		keys = append(keys, hostnameKey(wildcard))
+	}
+
+	return keys
+}
+
+func hostnames(object client.Object) (hostnames []string) {
+	switch ing := object.(type) {
+	case *capsulev1beta2.Tenant:
+		for _, shared := range ing.Spec.IngressOptions.AllowedPathPrefixes {
+			hostnames = append(hostnames, shared.Hostname)
+		}
+	case *networkingv1.Ingress:
+		for _, rule := range ing.Spec.Rules {
+			hostnames = append(hostnames, rule.Host)
+		}
+	case *networkingv1beta1.Ingress:
+		for _, rule := range ing.Spec.Rules {
+			hostnames = append(hostnames, rule.Host)
+		}
+	case *extensionsv1beta1.Ingress:
+		for _, rule := range ing.Spec.Rules {
+			hostnames = append(hostnames, rule.Host)
+		}
+	case *unstructured.Unstructured:
+		if ing.GroupVersionKind() != OpenShiftRouteGroupVersionKind {
+			return nil
+		}
+
+		host, _, _ := unstructured.NestedString(ing.Object, "spec", "host")
+		policy, _, _ := unstructured.NestedString(ing.Object, "spec", "wildcardPolicy")
+		// With the Subdomain wildcard policy, the Route is serving all the hosts of the parent domain.
+		if wildcard, ok := matchingWildcard(host); ok && policy == "Subdomain" {
+			host = wildcard
+		}
+
+		hostnames = append(hostnames, host)
+	}
+
+	return hostnames
+}
+
+// matchingWildcard returns the wildcard hostname matching the given exact one, such as *.acme.com for www.acme.com.
+func matchingWildcard(hostname string) (string, bool) {
+	if isWildcard(hostname) {
+		return "", false
+	}
+
+	_, domain, found := strings.Cut(hostname, ".")
+	if !found || domain == "" {
+		return "", false
+	}
+
+	return "*." + domain, true
+}
+
+func isWildcard(hostname string) bool {
+	return strings.HasPrefix(hostname, "*.")
+}
+
+func hostnameKey(hostname string) string {
+	return hostnameKeyPrefix + strings.ToLower(hostname)
+}
+
+func wildcardKey(wildcard string) string {
+	return wildcardKeyPrefix + strings.ToLower(wildcard)
+}
@@ -0,0 +1,51 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ExternalIPsField = ".spec.externalIPs"
+)
+
+// ExternalIPs indexes the Services by external IPs, in their canonical form, such as 2001:db8::1 for 2001:0db8::0001.
+type ExternalIPs struct{}
+
+func (ExternalIPs) Object() client.Object {
+	return &corev1.Service{}
+}
+
+func (ExternalIPs) Field() string {
+	return ExternalIPsField
+}
+
+func (ExternalIPs) Func() client.IndexerFunc {
+	return func(object client.Object) (entries []string) {
+		svc, ok := object.(*corev1.Service)
+		if !ok {
+			panic(fmt.Errorf("expected *corev1.Service, got %T", object))
+		}
+
+		for _, ip := range svc.Spec.ExternalIPs {
+			entries = append(entries, CanonicalIP(ip))
+		}
+
+		return entries
+	}
+}
+
+// CanonicalIP returns the canonical form of the given IP, or the value itself if it's not a valid IP.
+func CanonicalIP(value string) string {
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String()
+	}
+
+	return value
+}
@@ -0,0 +1,37 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TypeField = ".spec.type"
+)
+
+// Type indexes the Services by type, allowing to count the LoadBalancer ones of a Namespace from the cache.
+type Type struct{}
+
+func (Type) Object() client.Object {
+	return &corev1.Service{}
+}
+
+func (Type) Field() string {
+	return TypeField
+}
+
+func (Type) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		svc, ok := object.(*corev1.Service)
+		if !ok {
+			panic(fmt.Errorf("expected *corev1.Service, got %T", object))
+		}
+
+		return []string{string(svc.Spec.Type)}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const HostnameClaimsField = ".spec.ingressOptions.hostnameClaims"
+
+// HostnameClaims indexes the Tenants by their hostname claims, allowing to look up the overlapping ones
+// with the index values returned by HostnameClaim.OverlappingIndexValues.
+type HostnameClaims struct{}
+
+func (h HostnameClaims) Object() client.Object {
+	return &capsulev1beta2.Tenant{}
+}
+
+func (h HostnameClaims) Field() string {
+	return HostnameClaimsField
+}
+
+func (h HostnameClaims) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tenant, ok := object.(*capsulev1beta2.Tenant)
+		if !ok {
+			panic(fmt.Errorf("expected type *capsulev1beta2.Tenant, got %T", tenant))
+		}
+
+		values := sets.New[string]()
+
+		for _, claim := range tenant.Spec.IngressOptions.HostnameClaims {
+			values.Insert(claim.IndexValues()...)
+		}
+
+		return sets.List(values)
+	}
+}
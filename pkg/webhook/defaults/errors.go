@@ -54,3 +54,39 @@ func NewPriorityClassError(class string, msg error) error {
 func (e PriorityClassError) Error() string {
 	return fmt.Sprintf("Failed to resolve Priority Class %s: %s", e.priorityClass, e.msg)
 }
+
+type ImagePullSecretError struct {
+	secret    string
+	namespace string
+}
+
+func NewImagePullSecretError(secret, namespace string) error {
+	return &ImagePullSecretError{
+		secret:    secret,
+		namespace: namespace,
+	}
+}
+
+func (e ImagePullSecretError) Error() string {
+	return fmt.Sprintf("The image pull Secret %s required by the Tenant is missing in the Namespace %s", e.secret, e.namespace)
+}
+
+type ExternalIPPoolExhaustedError struct{}
+
+func NewExternalIPPoolExhaustedError() error {
+	return &ExternalIPPoolExhaustedError{}
+}
+
+func (e ExternalIPPoolExhaustedError) Error() string {
+	return "No free external IP is left among the ones allowed for the current Tenant: please, reach out to the system administrators"
+}
+
+type IngressGenerateNameTLSError struct{}
+
+func NewIngressGenerateNameTLSError() error {
+	return &IngressGenerateNameTLSError{}
+}
+
+func (e IngressGenerateNameTLSError) Error() string {
+	return "the Ingress is using generateName, thus its name is not known upon admission and the TLS Secret name cannot be rendered"
+}
@@ -0,0 +1,167 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/webhook/pod"
+)
+
+// handleRegistryMirrors rewrites the container images according to the given mirrors, looked up in order,
+// recording the original images in the object annotations, keyed by container name.
+func handleRegistryMirrors(spec *corev1.PodSpec, meta *metav1.ObjectMeta, mirrors ...[]api.RegistryMirrorSpec) (mutated bool, err error) {
+	originals := map[string]string{}
+
+	rewrite := func(name string, image *string) {
+		ref := pod.NewImageRef(*image)
+
+		mirror, ok := api.MirrorFor(ref.Registry, mirrors...)
+		if !ok {
+			return
+		}
+
+		ref.Registry = mirror
+
+		originals[name], *image = *image, ref.String()
+	}
+
+	for i := range spec.InitContainers {
+		rewrite(spec.InitContainers[i].Name, &spec.InitContainers[i].Image)
+	}
+
+	for i := range spec.Containers {
+		rewrite(spec.Containers[i].Name, &spec.Containers[i].Image)
+	}
+
+	for i := range spec.EphemeralContainers {
+		rewrite(spec.EphemeralContainers[i].Name, &spec.EphemeralContainers[i].Image)
+	}
+
+	if len(originals) == 0 {
+		return false, nil
+	}
+
+	// Preserving the images recorded by previous rewrites, such as for the already running containers.
+	if previous, ok := meta.GetAnnotations()[api.OriginalImagesAnnotation]; ok {
+		recorded := map[string]string{}
+
+		if json.Unmarshal([]byte(previous), &recorded) == nil {
+			for name, image := range recorded {
+				if _, ok := originals[name]; !ok {
+					originals[name] = image
+				}
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(originals)
+	if err != nil {
+		return false, fmt.Errorf("cannot record the original images: %w", err)
+	}
+
+	annotations := meta.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[api.OriginalImagesAnnotation] = string(encoded)
+
+	meta.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+// handleOriginalImagesAnnotation drops the images recorded in the object annotations which the containers are not the mirror of,
+// such as the ones set by the Tenant owners upon creation: only the images rewritten by Capsule are kept,
+// including the ones recorded in the Pod templates of the workload controllers, and copied to their Pods.
+func handleOriginalImagesAnnotation(spec *corev1.PodSpec, meta *metav1.ObjectMeta, mirrors ...[]api.RegistryMirrorSpec) (mutated bool, err error) {
+	annotations := meta.GetAnnotations()
+	if _, ok := annotations[api.OriginalImagesAnnotation]; !ok {
+		return false, nil
+	}
+
+	images := map[string]string{}
+
+	for _, container := range append(append(spec.InitContainers, spec.Containers...), ephemeralContainers(spec)...) {
+		images[container.Name] = container.Image
+	}
+
+	recorded := pod.OriginalImages(annotations)
+
+	for name, original := range recorded {
+		if image, ok := images[name]; !ok || !pod.IsMirroredImage(image, original, mirrors...) {
+			delete(recorded, name)
+		}
+	}
+
+	if len(recorded) == 0 {
+		delete(annotations, api.OriginalImagesAnnotation)
+		meta.SetAnnotations(annotations)
+
+		return true, nil
+	}
+
+	encoded, err := json.Marshal(recorded)
+	if err != nil {
+		return false, fmt.Errorf("cannot record the original images: %w", err)
+	}
+
+	if string(encoded) == annotations[api.OriginalImagesAnnotation] {
+		return false, nil
+	}
+
+	annotations[api.OriginalImagesAnnotation] = string(encoded)
+	meta.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+func ephemeralContainers(spec *corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.EphemeralContainers))
+
+	for _, container := range spec.EphemeralContainers {
+		containers = append(containers, corev1.Container{Name: container.Name, Image: container.Image})
+	}
+
+	return containers
+}
+
+// handleImagePullPolicyDefaults assigns the default pull policy of the matching rule to the containers
+// using a policy the rule doesn't allow: the rules are matching the images as requested, before the registry mirrors rewrite.
+func handleImagePullPolicyDefaults(spec *corev1.PodSpec, meta *metav1.ObjectMeta, rules []api.ImagePullPolicyRule, mirrors ...[]api.RegistryMirrorSpec) (mutated bool) {
+	assign := func(name, image string, policy *corev1.PullPolicy) {
+		rule := pod.ImagePullPolicyRuleFor(pod.SourceImage(meta.GetAnnotations(), name, image, mirrors...), rules)
+		if rule == nil || len(rule.Default) == 0 {
+			return
+		}
+
+		for _, allowed := range rule.AllowedPolicies {
+			if string(allowed) == string(*policy) {
+				return
+			}
+		}
+
+		*policy, mutated = corev1.PullPolicy(rule.Default), true
+	}
+
+	for i := range spec.InitContainers {
+		assign(spec.InitContainers[i].Name, spec.InitContainers[i].Image, &spec.InitContainers[i].ImagePullPolicy)
+	}
+
+	for i := range spec.Containers {
+		assign(spec.Containers[i].Name, spec.Containers[i].Image, &spec.Containers[i].ImagePullPolicy)
+	}
+
+	for i := range spec.EphemeralContainers {
+		assign(spec.EphemeralContainers[i].Name, spec.EphemeralContainers[i].Image, &spec.EphemeralContainers[i].ImagePullPolicy)
+	}
+
+	return mutated
+}
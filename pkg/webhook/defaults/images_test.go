@@ -0,0 +1,122 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestHandleRegistryMirrors(t *testing.T) {
+	tenant := []api.RegistryMirrorSpec{{Registry: "docker.io", Mirror: "mirror.corp/dockerhub"}}
+	global := []api.RegistryMirrorSpec{
+		{Registry: "docker.io", Mirror: "global.corp/dockerhub"},
+		{Registry: "quay.io", Mirror: "global.corp/quay"},
+	}
+
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36"}},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "quay.io/org/app@sha256:abc"},
+			{Name: "internal", Image: "registry.corp/team-a/app:v1"},
+		},
+		EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "index.docker.io/bitnami/kubectl"}}},
+	}
+	meta := &metav1.ObjectMeta{Annotations: map[string]string{api.OriginalImagesAnnotation: `{"previous":"docker.io/library/nginx"}`}}
+
+	mutated, err := handleRegistryMirrors(spec, meta, tenant, global)
+	require.NoError(t, err)
+	assert.True(t, mutated)
+
+	assert.Equal(t, "mirror.corp/dockerhub/library/busybox:1.36", spec.InitContainers[0].Image)
+	assert.Equal(t, "global.corp/quay/org/app@sha256:abc", spec.Containers[0].Image)
+	assert.Equal(t, "registry.corp/team-a/app:v1", spec.Containers[1].Image)
+	assert.Equal(t, "mirror.corp/dockerhub/bitnami/kubectl", spec.EphemeralContainers[0].Image)
+	assert.JSONEq(t, `{"init":"busybox:1.36","app":"quay.io/org/app@sha256:abc","debug":"index.docker.io/bitnami/kubectl","previous":"docker.io/library/nginx"}`, meta.Annotations[api.OriginalImagesAnnotation])
+
+	// Already mirrored images are left untouched.
+	mutated, err = handleRegistryMirrors(spec, meta, tenant, global)
+	require.NoError(t, err)
+	assert.False(t, mutated)
+}
+
+func TestHandleImagePullPolicyDefaults(t *testing.T) {
+	rules := []api.ImagePullPolicyRule{
+		{Pattern: "registry.corp", AllowedPolicies: []api.ImagePullPolicySpec{"Always"}, Default: "Always"},
+		{Pattern: "quay.io", AllowedPolicies: []api.ImagePullPolicySpec{"IfNotPresent"}},
+	}
+
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: "registry.corp/team-a/init:v1", ImagePullPolicy: corev1.PullIfNotPresent}},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "registry.corp/team-a/app:main", ImagePullPolicy: corev1.PullAlways},
+			{Name: "sidecar", Image: "quay.io/org/sidecar:v1", ImagePullPolicy: corev1.PullAlways},
+		},
+	}
+
+	assert.True(t, handleImagePullPolicyDefaults(spec, &metav1.ObjectMeta{}, rules))
+	assert.Equal(t, corev1.PullAlways, spec.InitContainers[0].ImagePullPolicy)
+	assert.Equal(t, corev1.PullAlways, spec.Containers[0].ImagePullPolicy)
+	// Rules without a default are left to the validation.
+	assert.Equal(t, corev1.PullAlways, spec.Containers[1].ImagePullPolicy)
+
+	assert.False(t, handleImagePullPolicyDefaults(spec, &metav1.ObjectMeta{}, rules))
+}
+
+func TestHandleImagePullPolicyDefaultsMirrored(t *testing.T) {
+	rules := []api.ImagePullPolicyRule{{Pattern: "docker.io", AllowedPolicies: []api.ImagePullPolicySpec{"Always"}, Default: "Always"}}
+
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "app", Image: "busybox:1.36", ImagePullPolicy: corev1.PullIfNotPresent}},
+	}
+	meta := &metav1.ObjectMeta{}
+	mirrors := []api.RegistryMirrorSpec{{Registry: "docker.io", Mirror: "mirror.corp/dockerhub"}}
+
+	mutated, err := handleRegistryMirrors(spec, meta, mirrors)
+	assert.NoError(t, err)
+	assert.True(t, mutated)
+
+	// The rules are matching the source registry, rather than the mirror.
+	assert.True(t, handleImagePullPolicyDefaults(spec, meta, rules, mirrors))
+	assert.Equal(t, corev1.PullAlways, spec.Containers[0].ImagePullPolicy)
+
+	// The recorded image is ignored once the container image has been changed.
+	spec.Containers[0].Image, spec.Containers[0].ImagePullPolicy = "mirror.corp/dockerhub/library/nginx:1.27", corev1.PullIfNotPresent
+
+	assert.False(t, handleImagePullPolicyDefaults(spec, meta, rules, mirrors))
+}
+
+func TestHandleOriginalImagesAnnotation(t *testing.T) {
+	mirrors := []api.RegistryMirrorSpec{{Registry: "docker.io", Mirror: "mirror.corp/dockerhub"}}
+
+	spec := &corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app", Image: "mirror.corp/dockerhub/library/busybox:1.36"},
+		{Name: "sidecar", Image: "registry.corp/team/app:v1"},
+	}}
+	// The image of the app container has been rewritten by Capsule in the Pod template, the sidecar one is forged.
+	meta := &metav1.ObjectMeta{Annotations: map[string]string{
+		api.OriginalImagesAnnotation: `{"app":"busybox:1.36","sidecar":"quay.io/team/app:v1"}`,
+	}}
+
+	mutated, err := handleOriginalImagesAnnotation(spec, meta, mirrors)
+	assert.NoError(t, err)
+	assert.True(t, mutated)
+	assert.Equal(t, `{"app":"busybox:1.36"}`, meta.Annotations[api.OriginalImagesAnnotation])
+
+	mutated, err = handleOriginalImagesAnnotation(spec, meta, mirrors)
+	assert.NoError(t, err)
+	assert.False(t, mutated)
+
+	// Without any mirror, no recorded image can be trusted.
+	mutated, err = handleOriginalImagesAnnotation(spec, meta)
+	assert.NoError(t, err)
+	assert.True(t, mutated)
+	assert.NotContains(t, meta.Annotations, api.OriginalImagesAnnotation)
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -16,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
 	capsuleingress "github.com/projectcapsule/capsule/pkg/webhook/ingress"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -38,32 +41,39 @@ func mutateIngressDefaults(ctx context.Context, req admission.Request, version *
 	if tnt == nil {
 		return nil
 	}
-	// Validate Default Ingress
-	allowed := tnt.Spec.IngressOptions.AllowedClasses
 
-	if allowed == nil || allowed.Default == "" {
-		return nil
+	classMutated, err := handleIngressClassDefault(ctx, version, c, tnt.Spec.IngressOptions.AllowedClasses, ingress)
+	if err != nil {
+		response := admission.Denied(err.Error())
+
+		return &response
 	}
 
-	var mutate bool
+	if classMutated {
+		recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Ingress Class %s to %s/%s", tnt.Spec.IngressOptions.AllowedClasses.Default, ingress.Name(), ingress.Namespace())
+	}
 
-	var ingressClass client.Object
+	var annotationsMutated, tlsMutated bool
+	// The annotations and the TLS block are defaults for new Ingresses only:
+	// upon update, they could have been removed on purpose.
+	if defaults := tnt.Spec.IngressOptions.Defaults; defaults != nil && req.Operation == admissionv1.Create {
+		if annotationsMutated = handleIngressAnnotationsDefault(defaults.Annotations, ingress); annotationsMutated {
+			recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default annotations to Ingress %s/%s", ingress.Namespace(), ingress.Name())
+		}
 
-	if ingressClassName := ingress.IngressClass(); ingressClassName != nil && *ingressClassName != allowed.Default {
-		if ingressClass, err = utils.GetIngressClassByName(ctx, version, c, ingressClassName); err != nil && !k8serrors.IsNotFound(err) {
-			response := admission.Denied(NewIngressClassError(*ingressClassName, err).Error())
+		// The TLS block is a best effort default: the Ingress is admitted anyway, warning the Tenant owners.
+		if tlsMutated, err = handleIngressTLSDefault(defaults.TLS, tnt.GetName(), ingress); err != nil {
+			recorder.Eventf(tnt, corev1.EventTypeWarning, "TenantDefaultSkipped", "Cannot assign Tenant default TLS block to an Ingress of Namespace %s: %s", ingress.Namespace(), err.Error())
+		}
 
-			return &response
+		if tlsMutated {
+			recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default TLS block to Ingress %s/%s", ingress.Namespace(), ingress.Name())
 		}
-	} else {
-		mutate = true
 	}
 
-	if mutate = mutate || (utils.IsDefaultIngressClass(ingressClass) && ingressClass.GetName() != allowed.Default); !mutate {
+	if !classMutated && !annotationsMutated && !tlsMutated {
 		return nil
 	}
-
-	ingress.SetIngressClass(allowed.Default)
 	// Marshal Manifest
 	marshaled, err := json.Marshal(ingress)
 	if err != nil {
@@ -72,9 +82,91 @@ func mutateIngressDefaults(ctx context.Context, req admission.Request, version *
 		return &response
 	}
 
-	recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Ingress Class %s to %s/%s", allowed.Default, ingress.Name(), ingress.Namespace())
-
 	response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
 
 	return &response
 }
+
+func handleIngressClassDefault(ctx context.Context, version *version.Version, c client.Client, allowed *api.DefaultAllowedListSpec, ingress capsuleingress.Ingress) (mutated bool, err error) {
+	if allowed == nil || allowed.Default == "" {
+		return false, nil
+	}
+
+	var ingressClass client.Object
+
+	if ingressClassName := ingress.IngressClass(); ingressClassName != nil && *ingressClassName != allowed.Default {
+		if ingressClass, err = utils.GetIngressClassByName(ctx, version, c, ingressClassName); err != nil && !k8serrors.IsNotFound(err) {
+			return false, NewIngressClassError(*ingressClassName, err)
+		}
+	} else {
+		mutated = true
+	}
+
+	if mutated = mutated || (utils.IsDefaultIngressClass(ingressClass) && ingressClass.GetName() != allowed.Default); !mutated {
+		return false, nil
+	}
+
+	ingress.SetIngressClass(allowed.Default)
+
+	return true, nil
+}
+
+// handleIngressAnnotationsDefault adds the default annotations the Ingress is not declaring yet,
+// leaving untouched the ones set by the user.
+func handleIngressAnnotationsDefault(defaults map[string]string, ingress capsuleingress.Ingress) (mutated bool) {
+	annotations := ingress.GetAnnotations()
+
+	for key, value := range defaults {
+		if _, ok := annotations[key]; ok {
+			continue
+		}
+
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[key] = value
+		mutated = true
+	}
+
+	if mutated {
+		ingress.SetAnnotations(annotations)
+	}
+
+	return mutated
+}
+
+// handleIngressTLSDefault adds a TLS block covering all the rule hosts to the Ingress having none.
+func handleIngressTLSDefault(defaults *capsulev1beta2.IngressDefaultTLS, tenant string, ingress capsuleingress.Ingress) (bool, error) {
+	if defaults == nil || len(ingress.TLS()) > 0 {
+		return false, nil
+	}
+
+	hosts := make([]string, 0, len(ingress.HostnamePathsPairs()))
+
+	for hostname := range ingress.HostnamePathsPairs() {
+		if hostname != "" {
+			hosts = append(hosts, hostname)
+		}
+	}
+
+	if len(hosts) == 0 {
+		return false, nil
+	}
+	// The name of the Ingresses using generateName is not known yet, thus it can't be used to render the Secret name.
+	if ingress.Name() == "" {
+		return false, NewIngressGenerateNameTLSError()
+	}
+
+	sort.Strings(hosts)
+
+	// The template is validated by the Tenant webhook, although Tenants created before could still carry an invalid one.
+	secretName, err := defaults.SecretName(tenant, ingress.Namespace(), ingress.Name())
+	if err != nil {
+		return false, err
+	}
+
+	ingress.SetTLS([]capsuleingress.TLSBlock{{Hosts: hosts, SecretName: secretName}})
+
+	return true, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsuleingress "github.com/projectcapsule/capsule/pkg/webhook/ingress"
+)
+
+func TestHandleIngressDefaults(t *testing.T) {
+	ingress := capsuleingress.NetworkingV1{Ingress: &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "oil-production",
+			Annotations: map[string]string{"cert-manager.io/cluster-issuer": "staging"},
+		},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "web.oil.acme.com"}, {Host: "api.oil.acme.com"}, {}}},
+	}}
+
+	assert.True(t, handleIngressAnnotationsDefault(map[string]string{
+		"cert-manager.io/cluster-issuer":        "production",
+		"nginx.ingress.kubernetes.io/limit-rps": "10",
+	}, ingress))
+	assert.Equal(t, map[string]string{
+		"cert-manager.io/cluster-issuer":        "staging",
+		"nginx.ingress.kubernetes.io/limit-rps": "10",
+	}, ingress.GetAnnotations())
+	assert.False(t, handleIngressAnnotationsDefault(map[string]string{"cert-manager.io/cluster-issuer": "production"}, ingress))
+
+	mutated, err := handleIngressTLSDefault(&capsulev1beta2.IngressDefaultTLS{SecretNameTemplate: "{{ tenant.name }}-{{ ingress.name }}-cert"}, "oil", ingress)
+	assert.NoError(t, err)
+	assert.True(t, mutated)
+	assert.Equal(t, []capsuleingress.TLSBlock{{Hosts: []string{"api.oil.acme.com", "web.oil.acme.com"}, SecretName: "oil-web-cert"}}, ingress.TLS())
+	// The TLS blocks declared by the user are never overwritten.
+	mutated, err = handleIngressTLSDefault(&capsulev1beta2.IngressDefaultTLS{}, "oil", ingress)
+	assert.NoError(t, err)
+	assert.False(t, mutated)
+}
+
+func TestHandleIngressTLSDefaultErrors(t *testing.T) {
+	generated := capsuleingress.NetworkingV1{Ingress: &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "web-", Namespace: "oil-production"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "web.oil.acme.com"}}},
+	}}
+
+	mutated, err := handleIngressTLSDefault(&capsulev1beta2.IngressDefaultTLS{}, "oil", generated)
+	assert.IsType(t, &IngressGenerateNameTLSError{}, err)
+	assert.False(t, mutated)
+	assert.Empty(t, generated.TLS())
+
+	named := capsuleingress.NetworkingV1{Ingress: &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "web.oil.acme.com"}}},
+	}}
+
+	mutated, err = handleIngressTLSDefault(&capsulev1beta2.IngressDefaultTLS{SecretNameTemplate: "{{ ingress.uid }}-tls"}, "oil", named)
+	assert.Error(t, err)
+	assert.False(t, mutated)
+	assert.Empty(t, named.TLS())
+}
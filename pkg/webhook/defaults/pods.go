@@ -6,20 +6,24 @@ package defaults
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	schedulev1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/configuration"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
 
-func mutatePodDefaults(ctx context.Context, req admission.Request, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, namespace string) *admission.Response {
+func mutatePodDefaults(ctx context.Context, req admission.Request, cfg configuration.Configuration, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, namespace string) *admission.Response {
 	var pod corev1.Pod
 	if err := decoder.Decode(req, &pod); err != nil {
 		return utils.ErroredResponse(err)
@@ -36,27 +40,108 @@ func mutatePodDefaults(ctx context.Context, req admission.Request, c client.Clie
 
 	var err error
 
-	pcMutated, pcErr := handlePriorityClassDefault(ctx, c, tnt.Spec.PriorityClasses, &pod)
-	if pcErr != nil {
-		return utils.ErroredResponse(pcErr)
-	} else if pcMutated {
+	// The ephemeral containers subresource is allowing the addition of ephemeral containers only:
+	// the other containers, as well as the Pod defaults, have been already handled upon the Pod creation.
+	ephemeral := req.SubResource == "ephemeralcontainers"
+
+	spec, meta := &pod.Spec, &pod.ObjectMeta
+	if ephemeral {
+		added, addedErr := addedEphemeralContainers(decoder, req, pod.Spec.EphemeralContainers)
+		if addedErr != nil {
+			return utils.ErroredResponse(addedErr)
+		}
+		// The changes to the metadata are dropped by the subresource, the original images cannot be recorded.
+		spec, meta = &corev1.PodSpec{EphemeralContainers: added}, &metav1.ObjectMeta{}
+	}
+
+	// The original images recorded upon creation are trusted only if Capsule has rewritten them, such as in the Pod template of a workload.
+	var originalsMutated bool
+
+	if req.Operation == admissionv1.Create {
+		var originalsErr error
+
+		if originalsMutated, originalsErr = handleOriginalImagesAnnotation(spec, meta, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors()); originalsErr != nil {
+			return utils.ErroredResponse(originalsErr)
+		}
+	}
+
+	mirrorMutated, mirrorErr := handleRegistryMirrors(spec, meta, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors())
+	if mirrorErr != nil {
+		return utils.ErroredResponse(mirrorErr)
+	} else if mirrorMutated {
 		defer func() {
 			if err == nil {
-				recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Priority Class %s to %s/%s", tnt.Spec.PriorityClasses.Default, pod.Namespace, pod.Name)
+				recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Rewritten container images of %s/%s to the registry mirrors", pod.Namespace, pod.Name)
 			}
 		}()
 	}
 
-	rcMutated := handleRuntimeClassDefault(tnt.Spec.RuntimeClasses, &pod)
-	if rcMutated {
+	pullPolicyMutated := handleImagePullPolicyDefaults(spec, meta, tnt.Spec.ImagePullPolicyRules, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors())
+	if pullPolicyMutated {
 		defer func() {
 			if err == nil {
-				recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Runtime Class %s to %s/%s", tnt.Spec.RuntimeClasses.Default, pod.Namespace, pod.Name)
+				recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default image pull policies to %s/%s", pod.Namespace, pod.Name)
 			}
 		}()
 	}
 
-	if !rcMutated && !pcMutated {
+	if ephemeral {
+		mergeEphemeralContainers(&pod.Spec, spec.EphemeralContainers)
+	}
+
+	var pcMutated, rcMutated, schedulingMutated, pullSecretsMutated bool
+
+	if !ephemeral {
+		var pcErr error
+
+		if pcMutated, pcErr = handlePriorityClassDefault(ctx, c, tnt.Spec.PriorityClasses, &pod); pcErr != nil {
+			return utils.ErroredResponse(pcErr)
+		} else if pcMutated {
+			defer func() {
+				if err == nil {
+					recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Priority Class %s to %s/%s", tnt.Spec.PriorityClasses.Default, pod.Namespace, pod.Name)
+				}
+			}()
+		}
+
+		if rcMutated = handleRuntimeClassDefault(tnt.Spec.RuntimeClasses, &pod); rcMutated {
+			defer func() {
+				if err == nil {
+					recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default Runtime Class %s to %s/%s", tnt.Spec.RuntimeClasses.Default, pod.Namespace, pod.Name)
+				}
+			}()
+		}
+
+		if schedulingMutated = handleSchedulingDefaults(tnt, &pod.Spec); schedulingMutated {
+			defer func() {
+				if err == nil {
+					recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant scheduling constraints to %s/%s", pod.Namespace, pod.Name)
+				}
+			}()
+		}
+
+		// The image pull Secrets of a Pod are immutable, they can be injected upon creation only.
+		if req.Operation == admissionv1.Create {
+			var psErr error
+
+			if pullSecretsMutated, psErr = handleImagePullSecrets(ctx, c, tnt, &pod); psErr != nil {
+				var secretErr *ImagePullSecretError
+				if errors.As(psErr, &secretErr) {
+					return ptr.To(admission.Denied(psErr.Error()))
+				}
+
+				return utils.ErroredResponse(psErr)
+			} else if pullSecretsMutated {
+				defer func() {
+					if err == nil {
+						recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant image pull Secrets to %s/%s", pod.Namespace, pod.Name)
+					}
+				}()
+			}
+		}
+	}
+
+	if !rcMutated && !pcMutated && !originalsMutated && !mirrorMutated && !schedulingMutated && !pullPolicyMutated && !pullSecretsMutated {
 		return nil
 	}
 
@@ -69,6 +154,42 @@ func mutatePodDefaults(ctx context.Context, req admission.Request, c client.Clie
 	return ptr.To(admission.PatchResponseFromRaw(req.Object.Raw, marshaled))
 }
 
+// addedEphemeralContainers returns the ephemeral containers added by the request:
+// the existing ones are immutable, and their images must be left untouched.
+func addedEphemeralContainers(decoder admission.Decoder, req admission.Request, containers []corev1.EphemeralContainer) ([]corev1.EphemeralContainer, error) {
+	old := &corev1.Pod{}
+	if err := decoder.DecodeRaw(req.OldObject, old); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]struct{}, len(old.Spec.EphemeralContainers))
+
+	for _, container := range old.Spec.EphemeralContainers {
+		existing[container.Name] = struct{}{}
+	}
+
+	var added []corev1.EphemeralContainer
+
+	for _, container := range containers {
+		if _, ok := existing[container.Name]; !ok {
+			added = append(added, container)
+		}
+	}
+
+	return added, nil
+}
+
+// mergeEphemeralContainers replaces the ephemeral containers of the Pod specification with the given ones, matching them by name.
+func mergeEphemeralContainers(spec *corev1.PodSpec, containers []corev1.EphemeralContainer) {
+	for _, container := range containers {
+		for i := range spec.EphemeralContainers {
+			if spec.EphemeralContainers[i].Name == container.Name {
+				spec.EphemeralContainers[i] = container
+			}
+		}
+	}
+}
+
 func handleRuntimeClassDefault(allowed *api.DefaultAllowedListSpec, pod *corev1.Pod) (mutated bool) {
 	if allowed == nil || allowed.Default == "" {
 		return false
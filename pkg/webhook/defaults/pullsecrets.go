@@ -0,0 +1,57 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// handleImagePullSecrets appends the Tenant image pull Secrets not already referenced by the Pod,
+// returning an error if any of them is missing in the Pod Namespace.
+func handleImagePullSecrets(ctx context.Context, c client.Client, tnt *capsulev1beta2.Tenant, pod *corev1.Pod) (mutated bool, err error) {
+	if tnt.Spec.PodOptions == nil {
+		return false, nil
+	}
+
+	for _, secret := range tnt.Spec.PodOptions.ImagePullSecrets {
+		if hasImagePullSecret(pod.Spec.ImagePullSecrets, secret.Name) {
+			continue
+		}
+
+		// Retrieving the metadata only, avoiding to cache the content of the Secrets.
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+		if err = c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: secret.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, NewImagePullSecretError(secret.Name, pod.Namespace)
+			}
+
+			return false, err
+		}
+
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, secret)
+		mutated = true
+	}
+
+	return mutated, nil
+}
+
+func hasImagePullSecret(secrets []corev1.LocalObjectReference, name string) bool {
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
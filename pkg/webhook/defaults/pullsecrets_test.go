@@ -0,0 +1,48 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestHandleImagePullSecrets(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "oil-production"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mirror", Namespace: "oil-production"}},
+	).Build()
+
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{PodOptions: &api.PodOptions{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+	}}}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "oil-production"},
+		Spec:       corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror"}}},
+	}
+
+	mutated, err := handleImagePullSecrets(context.Background(), c, tnt, pod)
+	require.NoError(t, err)
+	assert.True(t, mutated)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "mirror"}, {Name: "registry"}}, pod.Spec.ImagePullSecrets)
+
+	mutated, err = handleImagePullSecrets(context.Background(), c, tnt, pod)
+	require.NoError(t, err)
+	assert.False(t, mutated)
+
+	pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "oil-development"}}
+
+	_, err = handleImagePullSecrets(context.Background(), c, tnt, pod)
+	assert.ErrorAs(t, err, new(*ImagePullSecretError))
+}
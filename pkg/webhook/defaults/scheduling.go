@@ -0,0 +1,92 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// handleSchedulingDefaults injects the Tenant scheduling constraints into the Pod:
+// conflicting values are left untouched, letting the validation reject them.
+func handleSchedulingDefaults(tnt *capsulev1beta2.Tenant, spec *corev1.PodSpec) (mutated bool) {
+	options := tnt.Spec.SchedulingOptions
+	if options == nil {
+		return false
+	}
+
+	if options.EnforceNodeSelector {
+		for key, value := range tnt.Spec.NodeSelector {
+			if _, ok := spec.NodeSelector[key]; ok {
+				continue
+			}
+
+			if spec.NodeSelector == nil {
+				spec.NodeSelector = map[string]string{}
+			}
+
+			spec.NodeSelector[key], mutated = value, true
+		}
+	}
+
+	if options.RequiredNodeAffinity != nil && len(options.RequiredNodeAffinity.NodeSelectorTerms) > 0 {
+		mergeRequiredNodeAffinity(spec, options.RequiredNodeAffinity)
+
+		mutated = true
+	}
+
+	for _, toleration := range options.Tolerations {
+		if hasToleration(spec.Tolerations, toleration) {
+			continue
+		}
+
+		spec.Tolerations, mutated = append(spec.Tolerations, toleration), true
+	}
+
+	return mutated
+}
+
+// mergeRequiredNodeAffinity requires the Pod to satisfy both its own node selector terms, and the given ones:
+// since terms are ORed, while their requirements are ANDed, each term of the Pod is combined with each of the given terms.
+func mergeRequiredNodeAffinity(spec *corev1.PodSpec, required *corev1.NodeSelector) {
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	current := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if current == nil || len(current.NodeSelectorTerms) == 0 {
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required.DeepCopy()
+
+		return
+	}
+
+	terms := make([]corev1.NodeSelectorTerm, 0, len(current.NodeSelectorTerms)*len(required.NodeSelectorTerms))
+
+	for _, podTerm := range current.NodeSelectorTerms {
+		for _, tenantTerm := range required.NodeSelectorTerms {
+			term := podTerm.DeepCopy()
+			term.MatchExpressions = append(term.MatchExpressions, tenantTerm.DeepCopy().MatchExpressions...)
+			term.MatchFields = append(term.MatchFields, tenantTerm.DeepCopy().MatchFields...)
+
+			terms = append(terms, *term)
+		}
+	}
+
+	current.NodeSelectorTerms = terms
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.MatchToleration(&toleration) {
+			return true
+		}
+	}
+
+	return false
+}
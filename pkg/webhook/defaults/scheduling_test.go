@@ -0,0 +1,56 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestHandleSchedulingDefaults(t *testing.T) {
+	requirement := func(key string, values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: values}
+	}
+
+	toleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "oil", Effect: corev1.TaintEffectNoSchedule}
+
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{
+		NodeSelector: map[string]string{"pool": "oil"},
+		SchedulingOptions: &api.SchedulingOptions{
+			EnforceNodeSelector: true,
+			RequiredNodeAffinity: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("zone", "a")}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("zone", "b")}},
+			}},
+			Tolerations: []corev1.Toleration{toleration},
+		},
+	}}
+
+	spec := &corev1.PodSpec{
+		NodeSelector: map[string]string{"disk": "ssd"},
+		Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("arch", "arm64")}}},
+		}}},
+		Tolerations: []corev1.Toleration{toleration},
+	}
+
+	assert.True(t, handleSchedulingDefaults(tnt, spec))
+	assert.Equal(t, map[string]string{"disk": "ssd", "pool": "oil"}, spec.NodeSelector)
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("arch", "arm64"), requirement("zone", "a")}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("arch", "arm64"), requirement("zone", "b")}},
+	}, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	assert.Len(t, spec.Tolerations, 1)
+
+	// Conflicting node selector values are left to the validation.
+	spec = &corev1.PodSpec{NodeSelector: map[string]string{"pool": "gas"}}
+	handleSchedulingDefaults(tnt, spec)
+	assert.Equal(t, "gas", spec.NodeSelector["pool"])
+	assert.Equal(t, tnt.Spec.SchedulingOptions.RequiredNodeAffinity, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+}
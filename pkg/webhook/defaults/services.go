@@ -0,0 +1,92 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	serviceindexer "github.com/projectcapsule/capsule/pkg/indexer/service"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+func mutateServiceDefaults(ctx context.Context, req admission.Request, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, namespace string) *admission.Response {
+	svc := &corev1.Service{}
+	if err := decoder.Decode(req, svc); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	svc.SetNamespace(namespace)
+
+	if svc.GetAnnotations()[api.AssignExternalIPAnnotation] != "true" || len(svc.Spec.ExternalIPs) > 0 || svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return nil
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, svc.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	} else if tnt == nil {
+		return nil
+	}
+
+	if tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil || !tnt.Spec.ServiceOptions.ExternalServiceIPs.AutoAssign {
+		return nil
+	}
+
+	ip, err := freeExternalIP(ctx, c, tnt.Spec.ServiceOptions.ExternalServiceIPs)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if ip == nil {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ExternalIPPoolExhausted", "No free external IP can be assigned to %s/%s", svc.Namespace, svc.Name)
+
+		return ptr.To(admission.Denied(NewExternalIPPoolExhaustedError().Error()))
+	}
+
+	svc.Spec.ExternalIPs = []string{ip.String()}
+
+	marshaled, err := json.Marshal(svc)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned external IP %s to %s/%s", ip.String(), svc.Namespace, svc.Name)
+
+	return ptr.To(admission.PatchResponseFromRaw(req.Object.Raw, marshaled))
+}
+
+// freeExternalIP returns the first allowed external IP not used by any Service of the cluster, if any.
+func freeExternalIP(ctx context.Context, c client.Client, pool *api.ExternalServiceIPsSpec) (net.IP, error) {
+	var listErr error
+
+	ip, found := pool.NextIP(func(ip net.IP) bool {
+		list := &corev1.ServiceList{}
+		if err := c.List(ctx, list, client.MatchingFields{serviceindexer.ExternalIPsField: ip.String()}); err != nil {
+			listErr = err
+			// Stopping the lookup: the error is returned in place of the address.
+			return false
+		}
+
+		return len(list.Items) > 0
+	})
+
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	if !found {
+		return nil, nil //nolint:nilnil
+	}
+
+	return ip, nil
+}
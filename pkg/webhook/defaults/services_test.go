@@ -0,0 +1,50 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	serviceindexer "github.com/projectcapsule/capsule/pkg/indexer/service"
+)
+
+func TestFreeExternalIP(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	indexer := serviceindexer.ExternalIPs{}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(indexer.Object(), indexer.Field(), indexer.Func()).
+		WithObjects(
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"}, Spec: corev1.ServiceSpec{ExternalIPs: []string{"10.0.0.1"}}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "gas-production"}, Spec: corev1.ServiceSpec{ExternalIPs: []string{"10.0.0.2", "2001:0db8::0001"}}},
+		).
+		Build()
+
+	ip, err := freeExternalIP(context.Background(), c, &api.ExternalServiceIPsSpec{Allowed: []api.AllowedIP{"10.0.0.0/29"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.3", ip.String())
+	// The network and broadcast addresses are skipped.
+	ip, err = freeExternalIP(context.Background(), c, &api.ExternalServiceIPsSpec{Allowed: []api.AllowedIP{"10.0.0.0/30"}})
+	assert.NoError(t, err)
+	assert.Nil(t, ip)
+
+	ip, err = freeExternalIP(context.Background(), c, &api.ExternalServiceIPsSpec{Allowed: []api.AllowedIP{"2001:db8::1", "2001:db8::2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::2", ip.String())
+
+	ip, err = freeExternalIP(context.Background(), c, &api.ExternalServiceIPsSpec{Allowed: []api.AllowedIP{"10.0.0.2"}})
+	assert.NoError(t, err)
+	assert.Nil(t, ip)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// mutateWorkloadDefaults applies the Tenant defaults to the Pod template of the workload controllers,
+// in order to let them be validated, and rolled out, as they are going to run.
+func mutateWorkloadDefaults(ctx context.Context, req admission.Request, cfg configuration.Configuration, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, namespace string) *admission.Response {
+	// The Pod template of a Job is immutable: rewriting it on update would reject any change to the Job,
+	// such as to its labels, once the Tenant defaults are changed after its creation.
+	if req.Kind.Kind == "Job" && req.Operation == admissionv1.Update {
+		return nil
+	}
+
+	obj, template, err := podTemplate(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	} else if tnt == nil {
+		return nil
+	}
+
+	// The original images recorded upon creation are trusted only if Capsule has rewritten them.
+	var originalsMutated bool
+
+	if req.Operation == admissionv1.Create {
+		if originalsMutated, err = handleOriginalImagesAnnotation(&template.Spec, &template.ObjectMeta, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors()); err != nil {
+			return utils.ErroredResponse(err)
+		}
+	}
+
+	mirrorMutated, err := handleRegistryMirrors(&template.Spec, &template.ObjectMeta, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors())
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	pullPolicyMutated := handleImagePullPolicyDefaults(&template.Spec, &template.ObjectMeta, tnt.Spec.ImagePullPolicyRules, tnt.Spec.RegistryMirrors, cfg.RegistryMirrors())
+
+	if !originalsMutated && !mirrorMutated && !pullPolicyMutated {
+		return nil
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if mirrorMutated {
+		recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Rewritten container images of %s %s/%s to the registry mirrors", req.Kind.Kind, namespace, req.Name)
+	}
+
+	if pullPolicyMutated {
+		recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant default image pull policies to %s %s/%s", req.Kind.Kind, namespace, req.Name)
+	}
+
+	return ptr.To(admission.PatchResponseFromRaw(req.Object.Raw, marshaled))
+}
+
+// podTemplate returns the decoded workload controller, along with its Pod template.
+func podTemplate(decoder admission.Decoder, req admission.Request) (client.Object, *corev1.PodTemplateSpec, error) {
+	switch req.Kind.Kind {
+	case "Deployment":
+		obj := &appsv1.Deployment{}
+
+		return obj, &obj.Spec.Template, decoder.Decode(req, obj)
+	case "StatefulSet":
+		obj := &appsv1.StatefulSet{}
+
+		return obj, &obj.Spec.Template, decoder.Decode(req, obj)
+	case "DaemonSet":
+		obj := &appsv1.DaemonSet{}
+
+		return obj, &obj.Spec.Template, decoder.Decode(req, obj)
+	case "ReplicaSet":
+		obj := &appsv1.ReplicaSet{}
+
+		return obj, &obj.Spec.Template, decoder.Decode(req, obj)
+	case "Job":
+		obj := &batchv1.Job{}
+
+		return obj, &obj.Spec.Template, decoder.Decode(req, obj)
+	case "CronJob":
+		obj := &batchv1.CronJob{}
+
+		return obj, &obj.Spec.JobTemplate.Spec.Template, decoder.Decode(req, obj)
+	default:
+		return nil, nil, fmt.Errorf("unsupported kind %s, cannot extract the Pod template", req.Kind.Kind)
+	}
+}
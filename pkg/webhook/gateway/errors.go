@@ -0,0 +1,128 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
+)
+
+type gatewayClassForbiddenError struct {
+	gatewayClassName string
+	spec             api.AllowedListSpec
+}
+
+func NewGatewayClassForbidden(class string, spec api.AllowedListSpec) error {
+	return &gatewayClassForbiddenError{
+		gatewayClassName: class,
+		spec:             spec,
+	}
+}
+
+func (g gatewayClassForbiddenError) Error() string {
+	return fmt.Sprintf("Gateway Class %s is forbidden for the current Tenant%s", g.gatewayClassName, appendAllowedListError(g.spec))
+}
+
+type hostnamesNotValidError struct {
+	kind      string
+	hostnames []string
+	spec      api.AllowedListSpec
+}
+
+func NewHostnamesNotValid(kind string, hostnames []string, spec api.AllowedListSpec) error {
+	return &hostnamesNotValidError{kind: kind, hostnames: hostnames, spec: spec}
+}
+
+func (h hostnamesNotValidError) Error() string {
+	return fmt.Sprintf("%s hostnames %s are not valid for the current Tenant%s", h.kind, h.hostnames, appendAllowedListError(h.spec))
+}
+
+type emptyHostnameError struct {
+	kind string
+	spec api.AllowedListSpec
+}
+
+func NewEmptyHostname(kind string, spec api.AllowedListSpec) error {
+	return &emptyHostnameError{kind: kind, spec: spec}
+}
+
+func (e emptyHostnameError) Error() string {
+	return fmt.Sprintf("%s without hostname, matching any hostname, is not allowed for the current Tenant%s", e.kind, appendAllowedListError(e.spec))
+}
+
+type hostnameCollisionError struct {
+	route     ingress.Route
+	other     ingress.Route
+	kind      string
+	namespace string
+	name      string
+}
+
+func NewHostnameCollision(route, other ingress.Route, kind, namespace, name string) error {
+	return &hostnameCollisionError{route: route, other: other, kind: kind, namespace: namespace, name: name}
+}
+
+func (h hostnameCollisionError) Error() string {
+	if h.route.Path == "" && h.other.Path == "" {
+		return fmt.Sprintf("hostname %s is overlapping with the hostname %s of the %s %s/%s: please, reach out to the system administrators",
+			h.route.Hostname, h.other.Hostname, h.kind, h.namespace, h.name)
+	}
+
+	return fmt.Sprintf("hostname %s and path %s are overlapping with the hostname %s and path %s of the %s %s/%s: please, reach out to the system administrators",
+		h.route.Hostname, h.route.Path, h.other.Hostname, h.other.Path, h.kind, h.namespace, h.name)
+}
+
+type parentGatewayForbiddenError struct {
+	namespace string
+	name      string
+}
+
+func NewParentGatewayForbidden(namespace, name string) error {
+	return &parentGatewayForbiddenError{namespace: namespace, name: name}
+}
+
+func (p parentGatewayForbiddenError) Error() string {
+	return fmt.Sprintf("attaching to the Gateway %s/%s is forbidden for the current Tenant, only the Gateways of the Tenant and the allowed shared ones can be used", p.namespace, p.name)
+}
+
+//nolint:predeclared,revive
+func appendAllowedListError(spec api.AllowedListSpec) (append string) {
+	if len(spec.Exact) > 0 {
+		append = fmt.Sprintf(", specify one of the following (%s)", strings.Join(spec.Exact, ", "))
+	}
+
+	if len(spec.Regex) > 0 {
+		append += fmt.Sprintf(", or matching the regex %s", spec.Regex)
+	}
+
+	return
+}
+
+type wildcardHostnamesForbiddenError struct {
+	kind      string
+	hostnames []string
+}
+
+func NewWildcardHostnamesForbidden(kind string, hostnames []string) error {
+	return &wildcardHostnamesForbiddenError{kind: kind, hostnames: hostnames}
+}
+
+func (w wildcardHostnamesForbiddenError) Error() string {
+	return fmt.Sprintf("%s wildcard hostnames %s are forbidden for the current Tenant", w.kind, w.hostnames)
+}
+
+type hostnameClaimedError struct {
+	hostname string
+}
+
+func NewHostnameClaimed(hostname string) error {
+	return &hostnameClaimedError{hostname: hostname}
+}
+
+func (h hostnameClaimedError) Error() string {
+	return fmt.Sprintf("hostname %s is reserved to another Tenant: please, reach out to the system administrators", h.hostname)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	gatewayindexer "github.com/projectcapsule/capsule/pkg/indexer/gateway"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
+)
+
+func decode(t *testing.T, kind, namespace, raw string) *Resource {
+	t.Helper()
+
+	resource := &Resource{}
+	require.NoError(t, json.Unmarshal([]byte(raw), resource))
+
+	resource.Kind = kind
+	resource.Namespace = namespace
+
+	return resource
+}
+
+// fakeClient returns a client serving the Tenants, along with the Gateway API kinds as unstructured objects.
+func fakeClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: GroupName, Version: "v1"}})
+	mapper.Add(capsulev1beta2.GroupVersion.WithKind("Tenant"), meta.RESTScopeRoot)
+
+	for _, kind := range []string{KindGateway, KindHTTPRoute, KindGRPCRoute} {
+		mapper.Add(schema.GroupVersionKind{Group: GroupName, Version: "v1", Kind: kind}, meta.RESTScopeNamespace)
+	}
+
+	tenants := tenantindexer.NamespacesReference{Obj: &capsulev1beta2.Tenant{}}
+	claims := tenantindexer.HostnameClaims{}
+
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithIndex(tenants.Object(), tenants.Field(), tenants.Func()).
+		WithIndex(claims.Object(), claims.Field(), claims.Func())
+
+	for _, kind := range []string{KindGateway, KindHTTPRoute, KindGRPCRoute} {
+		hostnames := gatewayindexer.Hostnames{Kind: kind}
+
+		builder = builder.WithIndex(hostnames.Object(), hostnames.Field(), hostnames.Func())
+	}
+
+	return builder.WithObjects(objects...).Build()
+}
+
+// object returns the Gateway API resource with the given spec, as stored in the cache.
+func object(t *testing.T, kind, namespace, name, spec string) *unstructured.Unstructured {
+	t.Helper()
+
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, json.Unmarshal([]byte(`{"spec":`+spec+`}`), &obj.Object))
+
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: GroupName, Version: "v1", Kind: kind})
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	return obj
+}
+
+// request returns the admission request of the Gateway API resource with the given spec.
+func request(t *testing.T, kind, namespace, name, spec string) admission.Request {
+	t.Helper()
+
+	raw, err := json.Marshal(object(t, kind, namespace, name, spec))
+	require.NoError(t, err)
+
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: GroupName, Version: "v1", Kind: kind},
+		Namespace: namespace,
+		Name:      name,
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}}
+}
+
+func TestRoutes(t *testing.T) {
+	route := decode(t, "HTTPRoute", "oil-production", `{"spec":{"hostnames":["app.oil.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/api"},"method":"GET"},{"path":{"type":"Exact","value":"/healthz"}}]},{}]}}`)
+	assert.Equal(t, []ingress.Route{
+		{Hostname: "app.oil.com", Path: "/api", PathType: "Prefix"},
+		{Hostname: "app.oil.com", Path: "/healthz", PathType: "Exact"},
+		{Hostname: "app.oil.com", Path: "/", PathType: "Prefix"},
+	}, route.Routes())
+
+	grpcRoute := decode(t, "GRPCRoute", "oil-production", `{"spec":{"hostnames":["grpc.oil.com"],"rules":[{"matches":[{"method":{"service":"acme.Users","method":"Get"}},{"method":{"service":"acme.Orders"}},{"method":{"type":"RegularExpression","service":"acme.*"}}]}]}}`)
+	assert.Equal(t, []ingress.Route{
+		{Hostname: "grpc.oil.com", Path: "/acme.Users/Get", PathType: "Exact"},
+		{Hostname: "grpc.oil.com", Path: "/acme.Orders/", PathType: "Prefix"},
+		{Hostname: "grpc.oil.com", Path: "/", PathType: "Prefix"},
+	}, grpcRoute.Routes())
+
+	tlsRoute := decode(t, "TLSRoute", "oil-production", `{"spec":{}}`)
+	assert.Equal(t, []ingress.Route{{}}, tlsRoute.Routes())
+
+	gateway := decode(t, "Gateway", "oil-production", `{"spec":{"gatewayClassName":"public","listeners":[{"name":"https","hostname":"*.oil.com"},{"name":"http"}]}}`)
+	assert.Equal(t, sets.New("*.oil.com", ""), gateway.Hostnames())
+}
+
+func TestValidateHostnames(t *testing.T) {
+	options := capsulev1beta2.GatewayOptions{AllowedHostnames: &api.AllowedListSpec{Regex: `.*\.oil\.com`}}
+
+	assert.NoError(t, validateHostnames(decode(t, "HTTPRoute", "oil-production", `{"spec":{"hostnames":["app.oil.com"]}}`), options))
+	assert.Error(t, validateHostnames(decode(t, "HTTPRoute", "oil-production", `{"spec":{"hostnames":["app.gas.com"]}}`), options))
+	assert.Error(t, validateHostnames(decode(t, "HTTPRoute", "oil-production", `{"spec":{}}`), options))
+	assert.Error(t, validateHostnames(decode(t, "HTTPRoute", "oil-production", `{"spec":{"hostnames":["*.oil.com"]}}`), options))
+
+	options.AllowWildcardHostnames = true
+	assert.NoError(t, validateHostnames(decode(t, "HTTPRoute", "oil-production", `{"spec":{"hostnames":["*.oil.com"]}}`), options))
+}
+
+func TestValidateParentGateways(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{
+		Spec: capsulev1beta2.TenantSpec{GatewayOptions: &capsulev1beta2.GatewayOptions{
+			AllowedParentGateways: []capsulev1beta2.GatewayReference{{Namespace: "gateways", Name: "public"}},
+		}},
+		Status: capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production", "oil-development"}},
+	}
+
+	assert.NoError(t, validateParentGateways(decode(t, "HTTPRoute", "oil-production", `{"spec":{"parentRefs":[{"name":"public","namespace":"gateways"},{"name":"internal"},{"name":"own","namespace":"oil-development"}]}}`), tnt))
+	// Parents other than Gateways, such as Services for the mesh, are not restricted.
+	assert.NoError(t, validateParentGateways(decode(t, "HTTPRoute", "oil-production", `{"spec":{"parentRefs":[{"group":"","kind":"Service","name":"backend","namespace":"default"}]}}`), tnt))
+	assert.Error(t, validateParentGateways(decode(t, "HTTPRoute", "oil-production", `{"spec":{"parentRefs":[{"name":"private","namespace":"gateways"}]}}`), tnt))
+}
@@ -0,0 +1,219 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
+)
+
+const (
+	GroupName     = "gateway.networking.k8s.io"
+	KindGateway   = "Gateway"
+	KindHTTPRoute = "HTTPRoute"
+	KindGRPCRoute = "GRPCRoute"
+)
+
+// Resource is the subset of the Gateway API resources, Gateways and Routes, enforced by Capsule:
+// the fields not applying to the admitted kind are left empty.
+type Resource struct {
+	Kind              string `json:"kind,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ResourceSpec `json:"spec,omitempty"`
+}
+
+type ResourceSpec struct {
+	GatewayClassName string            `json:"gatewayClassName,omitempty"`
+	Listeners        []Listener        `json:"listeners,omitempty"`
+	ParentRefs       []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames        []string          `json:"hostnames,omitempty"`
+	Rules            []RouteRule       `json:"rules,omitempty"`
+}
+
+type Listener struct {
+	Name     string  `json:"name"`
+	Hostname *string `json:"hostname,omitempty"`
+}
+
+type ParentReference struct {
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Namespace *string `json:"namespace,omitempty"`
+	Name      string  `json:"name"`
+}
+
+type RouteRule struct {
+	Matches []RouteMatch `json:"matches,omitempty"`
+}
+
+type RouteMatch struct {
+	Path *PathMatch `json:"path,omitempty"`
+	// Method is a string for the HTTPRoutes, and a GRPCMethodMatch for the GRPCRoutes:
+	// it's decoded according to the kind.
+	Method json.RawMessage `json:"method,omitempty"`
+}
+
+type PathMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type GRPCMethodMatch struct {
+	Type    *string `json:"type,omitempty"`
+	Service *string `json:"service,omitempty"`
+	Method  *string `json:"method,omitempty"`
+}
+
+func (r *Resource) IsGateway() bool {
+	return r.Kind == KindGateway
+}
+
+// Hostnames returns the hostnames of the Gateway listeners, or of the Route:
+// an empty hostname means any hostname, as for listeners and Routes not specifying them.
+func (r *Resource) Hostnames() sets.Set[string] {
+	hostnames := sets.New[string]()
+
+	for _, route := range r.Routes() {
+		hostnames.Insert(route.Hostname)
+	}
+
+	return hostnames
+}
+
+// Routes returns the pairs of hostname and path served by the resource, along with the path type,
+// following the semantics of the Ingress routes: an empty hostname means any hostname.
+// Paths apply to the HTTPRoute and GRPCRoute resources only, the other kinds are reported with an empty path.
+func (r *Resource) Routes() []ingress.Route {
+	var routes []ingress.Route
+
+	if r.IsGateway() {
+		for _, listener := range r.Spec.Listeners {
+			hostname := ""
+			if listener.Hostname != nil {
+				hostname = *listener.Hostname
+			}
+
+			routes = append(routes, ingress.Route{Hostname: hostname})
+		}
+
+		return routes
+	}
+
+	var paths []ingress.Route
+
+	switch r.Kind {
+	case KindHTTPRoute:
+		for _, rule := range r.Spec.Rules {
+			for _, match := range rule.Matches {
+				paths = append(paths, match.Path.route())
+			}
+
+			if len(rule.Matches) == 0 {
+				paths = append(paths, matchAll)
+			}
+		}
+	case KindGRPCRoute:
+		for _, rule := range r.Spec.Rules {
+			for _, match := range rule.Matches {
+				paths = append(paths, grpcMethodRoute(match.Method))
+			}
+
+			if len(rule.Matches) == 0 {
+				paths = append(paths, matchAll)
+			}
+		}
+	}
+
+	// The HTTPRoutes and the GRPCRoutes without rules are matching any path.
+	switch {
+	case len(paths) > 0:
+	case r.Kind == KindHTTPRoute || r.Kind == KindGRPCRoute:
+		paths = append(paths, matchAll)
+	default:
+		paths = append(paths, ingress.Route{})
+	}
+
+	hostnames := r.Spec.Hostnames
+	if len(hostnames) == 0 {
+		hostnames = []string{""}
+	}
+
+	for _, hostname := range hostnames {
+		for _, path := range paths {
+			path.Hostname = hostname
+
+			routes = append(routes, path)
+		}
+	}
+
+	return routes
+}
+
+// matchAll is the route matching any path, as the HTTPRoute and GRPCRoute rules without matches.
+var matchAll = ingress.Route{Path: "/", PathType: "Prefix"}
+
+// route returns the path matched by the HTTPRoute: the PathPrefix matches are element-wise, as the Ingress Prefix ones,
+// while the RegularExpression ones are compared as is.
+func (m *PathMatch) route() ingress.Route {
+	if m == nil || m.Value == nil {
+		return matchAll
+	}
+
+	switch {
+	case m.Type == nil || *m.Type == "PathPrefix":
+		return ingress.Route{Path: *m.Value, PathType: "Prefix"}
+	default:
+		return ingress.Route{Path: *m.Value, PathType: *m.Type}
+	}
+}
+
+// grpcMethodRoute returns the path matched by the GRPCRoute method, being gRPC served over HTTP/2 at /<service>/<method>:
+// the matches by regular expression, or by method only, can match any path.
+func grpcMethodRoute(raw json.RawMessage) ingress.Route {
+	method := &GRPCMethodMatch{}
+	if len(raw) == 0 || json.Unmarshal(raw, method) != nil {
+		return matchAll
+	}
+
+	if method.Type != nil && *method.Type != "Exact" {
+		return matchAll
+	}
+
+	switch {
+	case method.Service == nil || *method.Service == "":
+		return matchAll
+	case method.Method == nil || *method.Method == "":
+		return ingress.Route{Path: "/" + *method.Service + "/", PathType: "Prefix"}
+	default:
+		return ingress.Route{Path: "/" + *method.Service + "/" + *method.Method, PathType: "Exact"}
+	}
+}
+
+// ParentGateways returns the Gateways the Route is attached to, along with their Namespace.
+func (r *Resource) ParentGateways() []ParentReference {
+	var gateways []ParentReference
+
+	for _, ref := range r.Spec.ParentRefs {
+		if ref.Group != nil && *ref.Group != GroupName {
+			continue
+		}
+
+		if ref.Kind != nil && *ref.Kind != KindGateway {
+			continue
+		}
+
+		namespace := r.Namespace
+		if ref.Namespace != nil {
+			namespace = *ref.Namespace
+		}
+
+		gateways = append(gateways, ParentReference{Namespace: &namespace, Name: ref.Name})
+	}
+
+	return gateways
+}
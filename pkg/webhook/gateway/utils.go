@@ -0,0 +1,42 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// FromRequest decodes the admitted Gateway API resource: the raw object is decoded as is,
+// since the Gateway API types are not part of the Capsule scheme.
+func FromRequest(req admission.Request) (*Resource, error) {
+	resource := &Resource{}
+	if err := json.Unmarshal(req.Object.Raw, resource); err != nil {
+		return nil, err
+	}
+
+	resource.Kind = req.Kind.Kind
+	resource.Namespace = req.Namespace
+
+	return resource, nil
+}
+
+// FromUnstructured decodes the Gateway API resource listed from the cache as an unstructured object.
+func FromUnstructured(obj *unstructured.Unstructured) (*Resource, error) {
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &Resource{}
+	if err = json.Unmarshal(raw, resource); err != nil {
+		return nil, err
+	}
+
+	resource.Kind = obj.GetKind()
+
+	return resource, nil
+}
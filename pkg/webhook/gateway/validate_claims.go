@@ -0,0 +1,75 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type claims struct{}
+
+func Claims() capsulewebhook.Handler {
+	return &claims{}
+}
+
+func (h *claims) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *claims) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *claims) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *claims) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil {
+		return nil
+	}
+
+	resource, err := FromRequest(req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	hostnames := resource.Hostnames().UnsortedList()
+	sort.Strings(hostnames)
+
+	claimed, err := utils.HostnameClaimedByOtherTenant(ctx, c, tnt.GetName(), hostnames)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if claimed != "" {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "GatewayHostnameClaimed", "%s %s/%s hostname %s is claimed by another Tenant", req.Kind.Kind, req.Namespace, req.Name, claimed)
+
+		response := admission.Denied(NewHostnameClaimed(claimed).Error())
+
+		return &response
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestClaims(t *testing.T) {
+	oil := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+	gas := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "gas"},
+		Spec: capsulev1beta2.TenantSpec{IngressOptions: capsulev1beta2.IngressOptions{
+			HostnameClaims: []api.HostnameClaim{"*.gas.acme.com", "portal.acme.com"},
+		}},
+		Status: capsulev1beta2.TenantStatus{Namespaces: []string{"gas-production"}},
+	}
+
+	validate := Claims().OnCreate(fakeClient(t, oil, gas), nil, record.NewFakeRecorder(10))
+
+	for _, tc := range []struct {
+		name      string
+		kind      string
+		namespace string
+		spec      string
+		allowed   bool
+	}{
+		{"hostname claimed by another Tenant", KindHTTPRoute, "oil-production", `{"hostnames":["www.gas.acme.com"]}`, false},
+		{"exact hostname claimed by another Tenant", KindGRPCRoute, "oil-production", `{"hostnames":["PORTAL.acme.com"]}`, false},
+		{"wildcard listener covering a claim", KindGateway, "oil-production", `{"listeners":[{"name":"https","hostname":"*.acme.com"}]}`, false},
+		{"hostname claimed by the same Tenant", KindHTTPRoute, "gas-production", `{"hostnames":["www.gas.acme.com"]}`, true},
+		{"hostname not claimed", KindHTTPRoute, "oil-production", `{"hostnames":["www.oil.acme.com"]}`, true},
+		{"Routes without hostnames", KindHTTPRoute, "oil-production", `{}`, true},
+	} {
+		response := validate(context.Background(), request(t, tc.kind, tc.namespace, "web", tc.spec))
+		assert.Equal(t, tc.allowed, response == nil, tc.name)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type class struct{}
+
+func Class() capsulewebhook.Handler {
+	return &class{}
+}
+
+func (h *class) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *class) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *class) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *class) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	if req.Kind.Kind != KindGateway {
+		return nil
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.GatewayOptions == nil || tnt.Spec.GatewayOptions.AllowedClasses == nil {
+		return nil
+	}
+
+	gateway, err := FromRequest(req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if allowed := tnt.Spec.GatewayOptions.AllowedClasses; !allowed.Match(gateway.Spec.GatewayClassName) {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenGatewayClass", "Gateway %s/%s GatewayClass %s is forbidden for the current Tenant", req.Namespace, req.Name, gateway.Spec.GatewayClassName)
+
+		response := admission.Denied(NewGatewayClassForbidden(gateway.Spec.GatewayClassName, *allowed).Error())
+
+		return &response
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestClass(t *testing.T) {
+	oil := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec: capsulev1beta2.TenantSpec{GatewayOptions: &capsulev1beta2.GatewayOptions{
+			AllowedClasses: &api.AllowedListSpec{Exact: []string{"public"}, Regex: "^internal-.*$"},
+		}},
+		Status: capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	validate := Class().OnCreate(fakeClient(t, oil), nil, record.NewFakeRecorder(10))
+
+	for _, tc := range []struct {
+		name    string
+		kind    string
+		spec    string
+		allowed bool
+	}{
+		{"exact allowed class", KindGateway, `{"gatewayClassName":"public"}`, true},
+		{"class matching the regex", KindGateway, `{"gatewayClassName":"internal-mesh"}`, true},
+		{"forbidden class", KindGateway, `{"gatewayClassName":"private"}`, false},
+		{"Routes are not subject to the classes", KindHTTPRoute, `{"hostnames":["app.oil.com"]}`, true},
+	} {
+		response := validate(context.Background(), request(t, tc.kind, "oil-production", "web", tc.spec))
+		assert.Equal(t, tc.allowed, response == nil, tc.name)
+	}
+
+	// Namespaces out of any Tenant are not subject to the classes.
+	assert.Nil(t, validate(context.Background(), request(t, KindGateway, "default", "web", `{"gatewayClassName":"private"}`)))
+}
@@ -0,0 +1,321 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	gatewayindexer "github.com/projectcapsule/capsule/pkg/indexer/gateway"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type collision struct{}
+
+func Collision() capsulewebhook.Handler {
+	return &collision{}
+}
+
+func (h *collision) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *collision) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *collision) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *collision) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.GatewayOptions == nil {
+		return nil
+	}
+
+	scope := tnt.Spec.GatewayOptions.HostnameCollisionScope
+	if scope == "" || scope == api.HostnameCollisionScopeDisabled {
+		return nil
+	}
+
+	resource, err := FromRequest(req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err = validateCollision(ctx, c, resource, scope); err == nil {
+		return nil
+	}
+
+	var collisionErr *hostnameCollisionError
+
+	if errors.As(err, &collisionErr) {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "GatewayHostnameCollision", "%s %s/%s hostname is colliding", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return utils.ErroredResponse(err)
+}
+
+// validateCollision returns an error if any other resource, within the given scope, is serving an overlapping pair of hostname and path:
+// HTTPRoutes and GRPCRoutes are checked against each other, since they're served by the same listeners.
+// Wildcard hostnames are matching any subdomain, as per the Gateway API specification, and the paths follow the Ingress semantics.
+// The Routes without hostnames are inheriting the ones of the listeners of their parent Gateways,
+// while the listeners without hostname are not taken into account, since these are matching any hostname.
+func validateCollision(ctx context.Context, c client.Client, resource *Resource, scope api.HostnameCollisionScope) error {
+	namespaces, err := ingress.CollisionNamespaces(ctx, c, resource.Namespace, scope)
+	if err != nil {
+		return err
+	}
+
+	listeners := &parentListeners{client: c, hostnames: map[types.NamespacedName][]string{}}
+
+	routes, err := listeners.routes(ctx, resource)
+	if err != nil || len(routes) == 0 {
+		return err
+	}
+
+	others, err := overlappingResources(ctx, c, resource, routes, namespaces)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range others {
+		otherRoutes, otherErr := listeners.routes(ctx, other)
+		if otherErr != nil {
+			return otherErr
+		}
+
+		for _, route := range routes {
+			for _, otherRoute := range otherRoutes {
+				if routesOverlap(route, otherRoute) {
+					return NewHostnameCollision(route, otherRoute, other.Kind, other.Namespace, other.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// overlappingResources returns the resources, of the kinds sharing the hostnames of the given one, in the given Namespaces,
+// with a hostname overlapping the ones of the given routes: they're retrieved from the cache by hostname,
+// along with the Routes without hostnames attached to the Gateways with an overlapping listener.
+func overlappingResources(ctx context.Context, c client.Client, resource *Resource, routes []ingress.Route, namespaces sets.Set[string]) (resources []*Resource, err error) {
+	keys := sets.New[string]()
+
+	for _, route := range routes {
+		keys.Insert(gatewayindexer.OverlappingHostnameKeys(route.Hostname)...)
+	}
+
+	var parents []*Resource
+
+	if !resource.IsGateway() {
+		for _, key := range sets.List(keys) {
+			var gateways []*Resource
+
+			if gateways, err = resourcesByIndex(ctx, c, KindGateway, key); err != nil {
+				return nil, err
+			}
+
+			parents = append(parents, gateways...)
+		}
+	}
+
+	found := sets.New[string]()
+
+	for _, kind := range collisionKinds(resource.Kind) {
+		kindKeys := sets.List(keys)
+
+		for _, parent := range parents {
+			kindKeys = append(kindKeys, gatewayindexer.ParentKey(parent.Namespace, parent.Name))
+		}
+
+		for _, key := range kindKeys {
+			var items []*Resource
+
+			if items, err = resourcesByIndex(ctx, c, kind, key); err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				id := kind + "/" + item.Namespace + "/" + item.Name
+
+				if !namespaces.Has(item.Namespace) || found.Has(id) {
+					continue
+				}
+
+				if kind == resource.Kind && item.Namespace == resource.Namespace && item.Name == resource.Name {
+					continue
+				}
+
+				found.Insert(id)
+
+				resources = append(resources, item)
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// resourcesByIndex returns the resources of the given kind matching the given hostname index value,
+// if the kind is served by the cluster.
+func resourcesByIndex(ctx context.Context, c client.Client, kind, key string) ([]*Resource, error) {
+	if _, err := c.RESTMapper().RESTMapping(schema.GroupKind{Group: GroupName, Kind: kind}, gatewayindexer.GroupVersion.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gatewayindexer.GroupVersion.WithKind(kind + "List"))
+
+	if err := c.List(ctx, list, client.MatchingFields{gatewayindexer.HostnamesField: key}); err != nil {
+		return nil, err
+	}
+
+	resources := make([]*Resource, 0, len(list.Items))
+
+	for i := range list.Items {
+		resource, err := FromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// collisionKinds returns the kinds sharing the hostnames, and the paths, of the given one.
+func collisionKinds(kind string) []string {
+	switch kind {
+	case KindHTTPRoute, KindGRPCRoute:
+		return []string{KindHTTPRoute, KindGRPCRoute}
+	default:
+		return []string{kind}
+	}
+}
+
+// routesOverlap returns true if any request can be matched by both the routes:
+// the hostnames overlap as the claims do, since the Gateway API wildcards are matching any subdomain.
+func routesOverlap(a, b ingress.Route) bool {
+	return api.HostnameClaim(a.Hostname).Overlaps(api.HostnameClaim(b.Hostname)) && a.PathOverlaps(b)
+}
+
+// parentListeners resolves the hostnames of the Routes without any, caching the listener hostnames of the parent Gateways.
+type parentListeners struct {
+	client    client.Client
+	hostnames map[types.NamespacedName][]string
+}
+
+// routes returns the routes of the resource having a hostname, inheriting the listener hostnames of the parent Gateways when missing.
+func (p *parentListeners) routes(ctx context.Context, resource *Resource) ([]ingress.Route, error) {
+	var routes []ingress.Route
+
+	for _, route := range resource.Routes() {
+		if route.Hostname != "" {
+			routes = append(routes, route)
+
+			continue
+		}
+
+		if resource.IsGateway() {
+			continue
+		}
+
+		for _, parent := range resource.ParentGateways() {
+			hostnames, err := p.listenerHostnames(ctx, types.NamespacedName{Namespace: *parent.Namespace, Name: parent.Name})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, hostname := range hostnames {
+				inherited := route
+				inherited.Hostname = hostname
+
+				routes = append(routes, inherited)
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+func (p *parentListeners) listenerHostnames(ctx context.Context, key types.NamespacedName) ([]string, error) {
+	if hostnames, ok := p.hostnames[key]; ok {
+		return hostnames, nil
+	}
+
+	mapping, err := p.client.RESTMapper().RESTMapping(schema.GroupKind{Group: GroupName, Kind: KindGateway})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+
+	if err = p.client.Get(ctx, key, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			p.hostnames[key] = nil
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	gateway, err := FromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var hostnames []string
+
+	for _, route := range gateway.Routes() {
+		if route.Hostname != "" {
+			hostnames = append(hostnames, route.Hostname)
+		}
+	}
+
+	p.hostnames[key] = hostnames
+
+	return hostnames, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestCollision(t *testing.T) {
+	tenant := func(name string, scope api.HostnameCollisionScope, namespaces ...string) *capsulev1beta2.Tenant {
+		return &capsulev1beta2.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       capsulev1beta2.TenantSpec{GatewayOptions: &capsulev1beta2.GatewayOptions{HostnameCollisionScope: scope}},
+			Status:     capsulev1beta2.TenantStatus{Namespaces: namespaces},
+		}
+	}
+
+	c := fakeClient(t,
+		tenant("oil", api.HostnameCollisionScopeCluster, "oil-production", "oil-development"),
+		tenant("gas", api.HostnameCollisionScopeCluster, "gas-production"),
+		tenant("water", api.HostnameCollisionScopeNamespace, "water-production"),
+		// The shared Gateway, out of any Tenant.
+		object(t, KindGateway, "gateways", "public", `{"listeners":[{"name":"https","hostname":"*.apps.acme.com"},{"name":"http"}]}`),
+		object(t, KindGateway, "gas-production", "edge", `{"listeners":[{"name":"https","hostname":"*.gas.acme.com"}]}`),
+		object(t, KindHTTPRoute, "gas-production", "api", `{"hostnames":["api.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/v1"}}]}]}`),
+		object(t, KindGRPCRoute, "gas-production", "users", `{"hostnames":["grpc.acme.com"],"rules":[{"matches":[{"method":{"service":"acme.Users"}}]}]}`),
+		object(t, KindHTTPRoute, "gas-production", "shop", `{"parentRefs":[{"name":"public","namespace":"gateways"}],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/shop"}}]}]}`),
+	)
+
+	validate := Collision().OnCreate(c, nil, record.NewFakeRecorder(10))
+
+	for _, tc := range []struct {
+		name      string
+		kind      string
+		namespace string
+		spec      string
+		allowed   bool
+	}{
+		{"overlapping prefix paths", KindHTTPRoute, "oil-production", `{"hostnames":["API.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/v1/users"}}]}]}`, false},
+		{"non overlapping paths", KindHTTPRoute, "oil-production", `{"hostnames":["api.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/v2"}}]}]}`, true},
+		{"HTTPRoute overlapping a GRPCRoute", KindHTTPRoute, "oil-production", `{"hostnames":["grpc.acme.com"],"rules":[{}]}`, false},
+		{"GRPCRoute overlapping an HTTPRoute", KindGRPCRoute, "oil-production", `{"hostnames":["api.acme.com"]}`, false},
+		{"GRPCRoute not overlapping the HTTPRoute paths", KindGRPCRoute, "oil-production", `{"hostnames":["api.acme.com"],"rules":[{"matches":[{"method":{"service":"acme.Users"}}]}]}`, true},
+		{"listener matched by a wildcard listener", KindGateway, "oil-production", `{"listeners":[{"name":"https","hostname":"www.gas.acme.com"}]}`, false},
+		{"wildcard listener covering a wildcard listener", KindGateway, "oil-production", `{"listeners":[{"name":"https","hostname":"*.acme.com"}]}`, false},
+		{"listeners without hostname", KindGateway, "oil-production", `{"listeners":[{"name":"http"}]}`, true},
+		{"hostname matched by the inherited wildcard listener", KindHTTPRoute, "oil-production", `{"hostnames":["www.apps.acme.com"],"rules":[{"matches":[{"path":{"type":"Exact","value":"/shop/cart"}}]}]}`, false},
+		{"subdomain matched at any depth by the inherited wildcard listener", KindHTTPRoute, "oil-production", `{"hostnames":["www.eu.apps.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/shop"}}]}]}`, false},
+		{"GRPCRoute matched by the inherited wildcard listener", KindGRPCRoute, "oil-production", `{"hostnames":["grpc.apps.acme.com"]}`, false},
+		{"Routes inheriting the same wildcard listener", KindHTTPRoute, "oil-production", `{"parentRefs":[{"name":"public","namespace":"gateways"}],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/shop"}}]}]}`, false},
+		{"Routes inheriting the same listener on other paths", KindHTTPRoute, "oil-production", `{"parentRefs":[{"name":"public","namespace":"gateways"}],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/blog"}}]}]}`, true},
+		{"the Route itself is ignored", KindHTTPRoute, "gas-production", `{"hostnames":["api.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/v1"}}]}]}`, true},
+		{"colliding Route out of the Namespace scope", KindHTTPRoute, "water-production", `{"hostnames":["api.acme.com"],"rules":[{"matches":[{"path":{"type":"PathPrefix","value":"/v1"}}]}]}`, true},
+	} {
+		name := "web"
+		if tc.namespace == "gas-production" {
+			name = "api"
+		}
+
+		response := validate(context.Background(), request(t, tc.kind, tc.namespace, name, tc.spec))
+		assert.Equal(t, tc.allowed, response == nil, tc.name)
+	}
+}
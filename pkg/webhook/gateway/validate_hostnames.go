@@ -0,0 +1,107 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type hostnames struct{}
+
+func Hostnames() capsulewebhook.Handler {
+	return &hostnames{}
+}
+
+func (h *hostnames) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *hostnames) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *hostnames) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *hostnames) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.GatewayOptions == nil {
+		return nil
+	}
+
+	resource, err := FromRequest(req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err = validateHostnames(resource, *tnt.Spec.GatewayOptions); err != nil {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "GatewayHostnameNotValid", "%s %s/%s hostname is not valid", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateHostnames returns an error if any of the hostnames is a forbidden wildcard, or is not allowed for the Tenant.
+func validateHostnames(resource *Resource, options capsulev1beta2.GatewayOptions) error {
+	var invalid []string
+
+	for hostname := range resource.Hostnames() {
+		if !options.AllowWildcardHostnames && strings.HasPrefix(hostname, "*") {
+			invalid = append(invalid, hostname)
+
+			continue
+		}
+
+		allowed := options.AllowedHostnames
+		if allowed == nil {
+			continue
+		}
+
+		if hostname == "" {
+			return NewEmptyHostname(resource.Kind, *allowed)
+		}
+
+		if !allowed.Match(hostname) {
+			invalid = append(invalid, hostname)
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	sort.Strings(invalid)
+
+	if options.AllowedHostnames == nil {
+		return NewWildcardHostnamesForbidden(resource.Kind, invalid)
+	}
+
+	return NewHostnamesNotValid(resource.Kind, invalid, *options.AllowedHostnames)
+}
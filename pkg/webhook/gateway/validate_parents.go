@@ -0,0 +1,100 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type parents struct{}
+
+func ParentGateways() capsulewebhook.Handler {
+	return &parents{}
+}
+
+func (h *parents) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *parents) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, req, recorder)
+	}
+}
+
+func (h *parents) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *parents) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	if req.Kind.Kind == KindGateway {
+		return nil
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.GatewayOptions == nil || len(tnt.Spec.GatewayOptions.AllowedParentGateways) == 0 {
+		return nil
+	}
+
+	route, err := FromRequest(req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err = validateParentGateways(route, tnt); err != nil {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenParentGateway", "%s %s/%s is attached to a Gateway forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateParentGateways returns an error if the Route is attached to a Gateway outside of the Tenant Namespaces,
+// and not listed among the allowed shared ones.
+func validateParentGateways(route *Resource, tnt *capsulev1beta2.Tenant) error {
+	namespaces := sets.New(tnt.Status.Namespaces...)
+
+	for _, parent := range route.ParentGateways() {
+		if namespaces.Has(*parent.Namespace) {
+			continue
+		}
+
+		allowed := false
+
+		for _, gateway := range tnt.Spec.GatewayOptions.AllowedParentGateways {
+			if gateway.Namespace == *parent.Namespace && gateway.Name == parent.Name {
+				allowed = true
+
+				break
+			}
+		}
+
+		if !allowed {
+			return NewParentGatewayForbidden(*parent.Namespace, parent.Name)
+		}
+	}
+
+	return nil
+}
@@ -36,15 +36,20 @@ type ingressHostnameNotValidError struct {
 }
 
 type ingressHostnameCollisionError struct {
-	hostname string
+	route     Route
+	other     Route
+	kind      string
+	namespace string
+	name      string
 }
 
 func (i ingressHostnameCollisionError) Error() string {
-	return fmt.Sprintf("hostname %s is already used across the cluster: please, reach out to the system administrators", i.hostname)
+	return fmt.Sprintf("hostname %s and path %s are overlapping with the hostname %s and path %s of the %s %s/%s: please, reach out to the system administrators",
+		i.route.Hostname, i.route.Path, i.other.Hostname, i.other.Path, i.kind, i.namespace, i.name)
 }
 
-func NewIngressHostnameCollision(hostname string) error {
-	return &ingressHostnameCollisionError{hostname: hostname}
+func NewIngressHostnameCollision(route, other Route, kind, namespace, name string) error {
+	return &ingressHostnameCollisionError{route: route, other: other, kind: kind, namespace: namespace, name: name}
 }
 
 func NewEmptyIngressHostname(spec api.AllowedListSpec) error {
@@ -114,3 +119,84 @@ func appendHostnameError(spec api.AllowedListSpec) (append string) {
 
 	return
 }
+
+type ingressHostnameClaimedError struct {
+	hostname string
+}
+
+func NewIngressHostnameClaimed(hostname string) error {
+	return &ingressHostnameClaimedError{hostname: hostname}
+}
+
+func (i ingressHostnameClaimedError) Error() string {
+	return fmt.Sprintf("hostname %s is reserved to another Tenant: please, reach out to the system administrators", i.hostname)
+}
+
+type ingressAnnotationNotAllowedError struct {
+	annotation string
+	spec       api.AllowedListSpec
+}
+
+func NewIngressAnnotationNotAllowed(annotation string, spec api.AllowedListSpec) error {
+	return &ingressAnnotationNotAllowedError{
+		annotation: annotation,
+		spec:       spec,
+	}
+}
+
+func (i ingressAnnotationNotAllowedError) Error() string {
+	return fmt.Sprintf("annotation %s is not allowed for the current Tenant%s", i.annotation, appendHostnameError(i.spec))
+}
+
+type ingressTLSNotValidError struct {
+	reason string
+}
+
+func NewIngressTLSNotValid(reason string) error {
+	return &ingressTLSNotValidError{reason: reason}
+}
+
+func (i ingressTLSNotValidError) Error() string {
+	return fmt.Sprintf("Ingress TLS is not valid for the current Tenant: %s", i.reason)
+}
+
+type ingressPathNotAllowedError struct {
+	hostname string
+	path     string
+	pathType string
+	prefixes []string
+}
+
+func NewIngressPathNotAllowed(hostname, path string, prefixes []string) error {
+	return &ingressPathNotAllowedError{hostname: hostname, path: path, prefixes: prefixes}
+}
+
+func NewIngressPathTypeNotAllowed(hostname, path, pathType string, prefixes []string) error {
+	return &ingressPathNotAllowedError{hostname: hostname, path: path, pathType: pathType, prefixes: prefixes}
+}
+
+func (i ingressPathNotAllowedError) Error() string {
+	if len(i.pathType) > 0 {
+		return fmt.Sprintf("%s on hostname %s uses the path type %s, not allowed for the current Tenant: use either Prefix, or Exact, within the following path prefixes (%s)", i.path, i.hostname, i.pathType, strings.Join(i.prefixes, ", "))
+	}
+
+	path := i.path
+	if path == "" {
+		path = "any path"
+	}
+
+	return fmt.Sprintf("%s on hostname %s is not allowed for the current Tenant, use one of the following path prefixes (%s)", path, i.hostname, strings.Join(i.prefixes, ", "))
+}
+
+type ingressHostnameReservedError struct {
+	hostname string
+	reserved string
+}
+
+func NewIngressHostnameReserved(hostname, reserved string) error {
+	return &ingressHostnameReservedError{hostname: hostname, reserved: reserved}
+}
+
+func (i ingressHostnameReservedError) Error() string {
+	return fmt.Sprintf("hostname %s overlaps %s, shared by other Tenants within their path prefixes, and not allowed for the current Tenant", i.hostname, i.reserved)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	OpenShiftRouteGroup   = "route.openshift.io"
+	OpenShiftRouteVersion = "v1"
+	OpenShiftRouteKind    = "Route"
+
+	openShiftWildcardPolicySubdomain = "Subdomain"
+)
+
+// OpenShiftRoute is the subset of the OpenShift route.openshift.io/v1 Route enforced by Capsule:
+// it's decoded from the raw object, since the OpenShift API types are not part of the Capsule scheme,
+// thus it can't be used to patch the admitted object.
+type OpenShiftRoute struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              OpenShiftRouteSpec `json:"spec,omitempty"`
+}
+
+type OpenShiftRouteSpec struct {
+	Host           string             `json:"host,omitempty"`
+	Path           string             `json:"path,omitempty"`
+	WildcardPolicy string             `json:"wildcardPolicy,omitempty"`
+	TLS            *OpenShiftRouteTLS `json:"tls,omitempty"`
+}
+
+type OpenShiftRouteTLS struct {
+	Termination string `json:"termination,omitempty"`
+}
+
+// IsOwnedByIngress returns true for the Routes generated by OpenShift from an Ingress, being owned by it.
+func (r *OpenShiftRoute) IsOwnedByIngress() bool {
+	for _, owner := range r.GetOwnerReferences() {
+		if owner.Kind == "Ingress" && strings.HasPrefix(owner.APIVersion, "networking.k8s.io/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OpenShiftRoutes have no class: the router shards are selecting them by labels.
+func (r *OpenShiftRoute) IngressClass() *string {
+	return nil
+}
+
+func (r *OpenShiftRoute) SetIngressClass(string) {}
+
+func (r *OpenShiftRoute) Namespace() string {
+	return r.GetNamespace()
+}
+
+func (r *OpenShiftRoute) Name() string {
+	return r.GetName()
+}
+
+// hostname returns the host served by the Route: with the Subdomain wildcard policy,
+// the Route is serving all the hosts of the parent domain, such as *.acme.com for www.acme.com.
+func (r *OpenShiftRoute) hostname() string {
+	if r.Spec.WildcardPolicy != openShiftWildcardPolicySubdomain {
+		return r.Spec.Host
+	}
+
+	if _, domain, found := strings.Cut(r.Spec.Host, "."); found {
+		return "*." + domain
+	}
+
+	return r.Spec.Host
+}
+
+func (r *OpenShiftRoute) HostnamePathsPairs() (pairs map[string]sets.Set[string]) {
+	return map[string]sets.Set[string]{r.hostname(): sets.New(r.Spec.Path)}
+}
+
+// Routes returns the single host and path pair of the Route: paths are matched as prefixes by the OpenShift router.
+func (r *OpenShiftRoute) Routes() []Route {
+	path := r.Spec.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return []Route{{Hostname: r.hostname(), Path: path, PathType: "Prefix"}}
+}
+
+// TLS returns a block for the Route host when TLS is enabled: the certificate is part of the Route itself,
+// or the default one of the router is used, thus no Secret is referenced.
+func (r *OpenShiftRoute) TLS() []TLSBlock {
+	if r.Spec.TLS == nil {
+		return nil
+	}
+
+	return []TLSBlock{{Hosts: []string{r.hostname()}}}
+}
+
+func (r *OpenShiftRoute) SetTLS([]TLSBlock) {}
@@ -0,0 +1,74 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type openShiftRouteHandler struct {
+	handlers []capsulewebhook.Handler
+}
+
+// OpenShiftRouteHandler runs the given handlers on the OpenShift Routes, except the ones generated by OpenShift from an Ingress:
+// these are owned by the Ingress, which has been already enforced upon its admission.
+func OpenShiftRouteHandler(handlers ...capsulewebhook.Handler) capsulewebhook.Handler {
+	return &openShiftRouteHandler{handlers: handlers}
+}
+
+func (h *openShiftRouteHandler) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.handle(ctx, req, func(hndl capsulewebhook.Handler) capsulewebhook.Func {
+			return hndl.OnCreate(client, decoder, recorder)
+		})
+	}
+}
+
+func (h *openShiftRouteHandler) OnDelete(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.handle(ctx, req, func(hndl capsulewebhook.Handler) capsulewebhook.Func {
+			return hndl.OnDelete(client, decoder, recorder)
+		})
+	}
+}
+
+func (h *openShiftRouteHandler) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.handle(ctx, req, func(hndl capsulewebhook.Handler) capsulewebhook.Func {
+			return hndl.OnUpdate(client, decoder, recorder)
+		})
+	}
+}
+
+func (h *openShiftRouteHandler) handle(ctx context.Context, req admission.Request, fn func(capsulewebhook.Handler) capsulewebhook.Func) *admission.Response {
+	raw := req.Object.Raw
+	if len(raw) == 0 {
+		raw = req.OldObject.Raw
+	}
+
+	route := &OpenShiftRoute{}
+	if err := json.Unmarshal(raw, route); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if route.IsOwnedByIngress() {
+		return nil
+	}
+
+	for _, hndl := range h.handlers {
+		if response := fn(hndl)(ctx, req); response != nil {
+			return response
+		}
+	}
+
+	return nil
+}
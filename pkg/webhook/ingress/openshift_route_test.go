@@ -0,0 +1,83 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+func TestOpenShiftRouteFromRequest(t *testing.T) {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: OpenShiftRouteGroup, Version: OpenShiftRouteVersion, Kind: OpenShiftRouteKind},
+		Namespace: "oil-production",
+		Object: runtime.RawExtension{Raw: []byte(`{
+			"apiVersion": "route.openshift.io/v1",
+			"kind": "Route",
+			"metadata": {"name": "web"},
+			"spec": {"host": "www.oil.acme.com", "path": "/api", "wildcardPolicy": "Subdomain", "to": {"kind": "Service", "name": "web"}}
+		}`)},
+	}}
+
+	ing, err := FromRequest(req, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "oil-production", ing.Namespace())
+	assert.Equal(t, "web", ing.Name())
+	assert.Equal(t, []Route{{Hostname: "*.oil.acme.com", Path: "/api", PathType: "Prefix"}}, ing.Routes())
+	assert.Contains(t, ing.HostnamePathsPairs(), "*.oil.acme.com")
+
+	other := &OpenShiftRoute{Spec: OpenShiftRouteSpec{Host: "api.oil.acme.com", Path: "/web"}}
+	assert.True(t, ing.Routes()[0].Overlaps(Route{Hostname: "api.oil.acme.com", Path: "/api/v1", PathType: "Prefix"}))
+	assert.False(t, ing.Routes()[0].Overlaps(other.Routes()[0]))
+}
+
+type denyingHandler struct{}
+
+func (denyingHandler) deny(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		response := admission.Denied("denied")
+
+		return &response
+	}
+}
+
+func (d denyingHandler) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return d.deny(c, decoder, recorder)
+}
+
+func (d denyingHandler) OnDelete(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return d.deny(c, decoder, recorder)
+}
+
+func (d denyingHandler) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return d.deny(c, decoder, recorder)
+}
+
+func TestOpenShiftRouteHandlerSkipsIngressOwned(t *testing.T) {
+	h := OpenShiftRouteHandler(denyingHandler{})
+
+	for raw, skipped := range map[string]bool{
+		`{"metadata": {"name": "web"}, "spec": {"host": "www.oil.acme.com"}}`: false,
+		`{"metadata": {"name": "web-x7k2p", "ownerReferences": [{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress", "name": "web", "uid": "1", "controller": true}]}, "spec": {"host": "www.oil.acme.com"}}`: true,
+		`{"metadata": {"name": "web", "ownerReferences": [{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "uid": "1"}]}, "spec": {"host": "www.oil.acme.com"}}`:                                     false,
+	} {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: "oil-production",
+			Object:    runtime.RawExtension{Raw: []byte(raw)},
+		}}
+
+		assert.Equal(t, skipped, h.OnCreate(nil, nil, nil)(context.Background(), req) == nil, raw)
+		assert.Equal(t, skipped, h.OnUpdate(nil, nil, nil)(context.Background(), req) == nil, raw)
+	}
+}
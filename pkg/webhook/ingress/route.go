@@ -0,0 +1,69 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"strings"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const (
+	pathTypeExact  = "Exact"
+	pathTypePrefix = "Prefix"
+)
+
+// Route is a pair of hostname and path served by an Ingress, along with the path type.
+type Route struct {
+	Hostname string
+	Path     string
+	PathType string
+}
+
+// Overlaps returns true if any request can be matched by both the routes.
+// Wildcard hostnames are matching a single DNS label, as per the Ingress specification: paths of type Prefix are
+// matched element-wise, while the ImplementationSpecific ones are compared as is, since their semantics depend on the controller.
+func (r Route) Overlaps(other Route) bool {
+	return hostnamesOverlap(r.Hostname, other.Hostname) && r.PathOverlaps(other)
+}
+
+func hostnamesOverlap(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+
+	switch {
+	case a == b:
+		return true
+	case strings.HasPrefix(a, "*.") && !strings.HasPrefix(b, "*."):
+		return matchesWildcard(a, b)
+	case strings.HasPrefix(b, "*.") && !strings.HasPrefix(a, "*."):
+		return matchesWildcard(b, a)
+	default:
+		return false
+	}
+}
+
+// matchesWildcard returns true if the hostname is matched by the wildcard one, such as foo.bar.com by *.bar.com.
+func matchesWildcard(wildcard, hostname string) bool {
+	suffix := strings.TrimPrefix(wildcard, "*")
+
+	label, found := strings.CutSuffix(hostname, suffix)
+
+	return found && label != "" && !strings.Contains(label, ".")
+}
+
+// PathOverlaps returns true if any request path can be matched by both the routes, regardless of their hostnames.
+func (r Route) PathOverlaps(other Route) bool {
+	a, b := r, other
+
+	switch {
+	case a.PathType == pathTypePrefix && b.PathType == pathTypePrefix:
+		return api.IsPathPrefix(a.Path, b.Path) || api.IsPathPrefix(b.Path, a.Path)
+	case a.PathType == pathTypePrefix && b.PathType == pathTypeExact:
+		return api.IsPathPrefix(a.Path, b.Path)
+	case a.PathType == pathTypeExact && b.PathType == pathTypePrefix:
+		return api.IsPathPrefix(b.Path, a.Path)
+	default:
+		return a.Path == b.Path
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteOverlaps(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     Route
+		overlaps bool
+	}{
+		{Route{"foo.apps.acme.com", "/", "Exact"}, Route{"foo.apps.acme.com", "/", "Exact"}, true},
+		{Route{"foo.apps.acme.com", "/", "Exact"}, Route{"bar.apps.acme.com", "/", "Exact"}, false},
+		{Route{"*.apps.acme.com", "/", "Prefix"}, Route{"foo.apps.acme.com", "/", "Prefix"}, true},
+		{Route{"*.apps.acme.com", "/", "Prefix"}, Route{"foo.bar.apps.acme.com", "/", "Prefix"}, false},
+		{Route{"*.apps.acme.com", "/", "Prefix"}, Route{"apps.acme.com", "/", "Prefix"}, false},
+		{Route{"*.apps.acme.com", "/", "Prefix"}, Route{"*.acme.com", "/", "Prefix"}, false},
+		{Route{"acme.com", "/api", "Exact"}, Route{"acme.com", "/api/v1", "Prefix"}, false},
+		{Route{"acme.com", "/api/v1", "Exact"}, Route{"acme.com", "/api", "Prefix"}, true},
+		{Route{"acme.com", "/api", "Prefix"}, Route{"acme.com", "/api/v1", "Prefix"}, true},
+		{Route{"acme.com", "/api", "Prefix"}, Route{"acme.com", "/apis", "Prefix"}, false},
+		{Route{"acme.com", "/", "Prefix"}, Route{"acme.com", "/apis", "Exact"}, true},
+		{Route{"acme.com", "/api/", "Prefix"}, Route{"acme.com", "/api", "Exact"}, true},
+		{Route{"acme.com", "/api", "ImplementationSpecific"}, Route{"acme.com", "/api/v1", "Prefix"}, false},
+		{Route{"acme.com", "/api", "ImplementationSpecific"}, Route{"acme.com", "/api", "Prefix"}, true},
+	} {
+		assert.Equal(t, tc.overlaps, tc.a.Overlaps(tc.b), "%v and %v", tc.a, tc.b)
+		assert.Equal(t, tc.overlaps, tc.b.Overlaps(tc.a), "%v and %v", tc.b, tc.a)
+	}
+}
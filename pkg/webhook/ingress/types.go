@@ -21,6 +21,11 @@ type Ingress interface {
 	Namespace() string
 	Name() string
 	HostnamePathsPairs() map[string]sets.Set[string]
+	Routes() []Route
+	TLS() []TLSBlock
+	SetTLS([]TLSBlock)
+	GetAnnotations() map[string]string
+	SetAnnotations(map[string]string)
 	SetIngressClass(string)
 	SetNamespace(string)
 }
@@ -68,6 +73,44 @@ func (n NetworkingV1) SetNamespace(ns string) {
 	n.Ingress.SetNamespace(ns)
 }
 
+func (n NetworkingV1) SetTLS(blocks []TLSBlock) {
+	n.Spec.TLS = make([]networkingv1.IngressTLS, 0, len(blocks))
+
+	for _, block := range blocks {
+		n.Spec.TLS = append(n.Spec.TLS, networkingv1.IngressTLS{Hosts: block.Hosts, SecretName: block.SecretName})
+	}
+}
+
+func (n NetworkingV1) TLS() (tls []TLSBlock) {
+	for _, t := range n.Spec.TLS {
+		tls = append(tls, TLSBlock{Hosts: t.Hosts, SecretName: t.SecretName})
+	}
+
+	return tls
+}
+
+func (n NetworkingV1) Routes() (routes []Route) {
+	for _, rule := range n.Spec.Rules {
+		// A rule with no paths is serving the whole hostname.
+		if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			routes = append(routes, Route{Hostname: rule.Host, Path: "/", PathType: pathTypePrefix})
+
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			route := Route{Hostname: rule.Host, Path: path.Path}
+			if path.PathType != nil {
+				route.PathType = string(*path.PathType)
+			}
+
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
 //nolint:dupl
 func (n NetworkingV1) HostnamePathsPairs() (pairs map[string]sets.Set[string]) {
 	pairs = make(map[string]sets.Set[string])
@@ -138,6 +181,44 @@ func (n NetworkingV1Beta1) SetNamespace(ns string) {
 	n.Ingress.SetNamespace(ns)
 }
 
+func (n NetworkingV1Beta1) SetTLS(blocks []TLSBlock) {
+	n.Spec.TLS = make([]networkingv1beta1.IngressTLS, 0, len(blocks))
+
+	for _, block := range blocks {
+		n.Spec.TLS = append(n.Spec.TLS, networkingv1beta1.IngressTLS{Hosts: block.Hosts, SecretName: block.SecretName})
+	}
+}
+
+func (n NetworkingV1Beta1) TLS() (tls []TLSBlock) {
+	for _, t := range n.Spec.TLS {
+		tls = append(tls, TLSBlock{Hosts: t.Hosts, SecretName: t.SecretName})
+	}
+
+	return tls
+}
+
+func (n NetworkingV1Beta1) Routes() (routes []Route) {
+	for _, rule := range n.Spec.Rules {
+		// A rule with no paths is serving the whole hostname.
+		if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			routes = append(routes, Route{Hostname: rule.Host, Path: "/", PathType: pathTypePrefix})
+
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			route := Route{Hostname: rule.Host, Path: path.Path}
+			if path.PathType != nil {
+				route.PathType = string(*path.PathType)
+			}
+
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
 //nolint:dupl
 func (n NetworkingV1Beta1) HostnamePathsPairs() (pairs map[string]sets.Set[string]) {
 	pairs = make(map[string]sets.Set[string])
@@ -206,6 +287,44 @@ func (e Extension) Namespace() string {
 	return e.GetNamespace()
 }
 
+func (e Extension) SetTLS(blocks []TLSBlock) {
+	e.Spec.TLS = make([]extensionsv1beta1.IngressTLS, 0, len(blocks))
+
+	for _, block := range blocks {
+		e.Spec.TLS = append(e.Spec.TLS, extensionsv1beta1.IngressTLS{Hosts: block.Hosts, SecretName: block.SecretName})
+	}
+}
+
+func (e Extension) TLS() (tls []TLSBlock) {
+	for _, t := range e.Spec.TLS {
+		tls = append(tls, TLSBlock{Hosts: t.Hosts, SecretName: t.SecretName})
+	}
+
+	return tls
+}
+
+func (e Extension) Routes() (routes []Route) {
+	for _, rule := range e.Spec.Rules {
+		// A rule with no paths is serving the whole hostname.
+		if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			routes = append(routes, Route{Hostname: rule.Host, Path: "/", PathType: pathTypePrefix})
+
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			route := Route{Hostname: rule.Host, Path: path.Path}
+			if path.PathType != nil {
+				route.PathType = string(*path.PathType)
+			}
+
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
 //nolint:dupl
 func (e Extension) HostnamePathsPairs() (pairs map[string]sets.Set[string]) {
 	pairs = make(map[string]sets.Set[string])
@@ -233,6 +352,12 @@ func (e Extension) HostnamePathsPairs() (pairs map[string]sets.Set[string]) {
 	return pairs
 }
 
+// TLSBlock is a TLS block of an Ingress, listing the hosts served using the certificate of the given Secret.
+type TLSBlock struct {
+	Hosts      []string
+	SecretName string
+}
+
 type HostnamesList []string
 
 func (h HostnamesList) Len() int {
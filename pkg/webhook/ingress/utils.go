@@ -5,6 +5,7 @@ package ingress
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
@@ -59,6 +60,15 @@ func FromRequest(req admission.Request, decoder admission.Decoder) (ingress Ingr
 		}
 
 		ingress = Extension{Ingress: ingressObj}
+	case OpenShiftRouteGroup:
+		route := &OpenShiftRoute{}
+		if err = json.Unmarshal(req.Object.Raw, route); err != nil {
+			return
+		}
+
+		route.SetNamespace(req.Namespace)
+
+		ingress = route
 	default:
 		err = fmt.Errorf("cannot recognize type %s", req.Kind.Group)
 	}
@@ -0,0 +1,117 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// allowedAnnotationPrefixes are the annotations managed by kubectl, and by Capsule itself, such as
+// kubectl.kubernetes.io/last-applied-configuration: they're not subject to the allowed annotations, but to the forbidden ones only.
+var allowedAnnotationPrefixes = []string{"kubectl.kubernetes.io/", "capsule.clastix.io/", "quota.capsule.clastix.io/"}
+
+type annotations struct{}
+
+func Annotations() capsulewebhook.Handler {
+	return &annotations{}
+}
+
+func (r *annotations) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *annotations) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *annotations) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *annotations) validate(ctx context.Context, client client.Client, req admission.Request, decoder admission.Decoder, recorder record.EventRecorder) *admission.Response {
+	ing, err := FromRequest(req, decoder)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := TenantFromIngress(ctx, client, ing)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil {
+		return nil
+	}
+
+	if err = validateAnnotations(ing.GetAnnotations(), tnt.Spec.IngressOptions.ForbiddenAnnotations, tnt.Spec.IngressOptions.AllowedAnnotations); err != nil {
+		err = errors.Wrap(err, "ingress annotations validation failed")
+		recorder.Eventf(tnt, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, "Ingress %s/%s: %s", ing.Namespace(), ing.Name(), err.Error())
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateAnnotations returns an error for the first annotation, in alphabetical order,
+// that is either forbidden or not matching the allowed ones, if any: the annotations managed by kubectl, and Capsule, are always allowed.
+func validateAnnotations(annotations map[string]string, forbidden api.ForbiddenListSpec, allowed *api.AllowedListSpec) error {
+	if err := api.ValidateForbidden(annotations, forbidden); err != nil {
+		return err
+	}
+
+	if allowed == nil || (len(allowed.Exact) == 0 && len(allowed.Regex) == 0) {
+		return nil
+	}
+
+	keys := make([]string, 0, len(annotations))
+
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if isManagedAnnotation(key) {
+			continue
+		}
+
+		if !allowed.Match(key) {
+			return NewIngressAnnotationNotAllowed(key, *allowed)
+		}
+	}
+
+	return nil
+}
+
+func isManagedAnnotation(key string) bool {
+	for _, prefix := range allowedAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type claims struct{}
+
+func Claims() capsulewebhook.Handler {
+	return &claims{}
+}
+
+func (r *claims) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *claims) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *claims) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *claims) validate(ctx context.Context, client client.Client, req admission.Request, decoder admission.Decoder, recorder record.EventRecorder) *admission.Response {
+	ing, err := FromRequest(req, decoder)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tenant, err := TenantFromIngress(ctx, client, ing)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tenant == nil {
+		return nil
+	}
+
+	hostnames := make([]string, 0, len(ing.HostnamePathsPairs()))
+
+	for hostname := range ing.HostnamePathsPairs() {
+		hostnames = append(hostnames, hostname)
+	}
+
+	sort.Strings(hostnames)
+
+	claimed, err := utils.HostnameClaimedByOtherTenant(ctx, client, tenant.GetName(), hostnames)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if claimed != "" {
+		recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressHostnameClaimed", "Ingress %s/%s hostname %s is claimed by another Tenant", ing.Namespace(), ing.Name(), claimed)
+
+		response := admission.Denied(NewIngressHostnameClaimed(claimed).Error())
+
+		return &response
+	}
+
+	return nil
+}
@@ -5,14 +5,17 @@ package ingress
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +24,7 @@ import (
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/configuration"
-	"github.com/projectcapsule/capsule/pkg/indexer/ingress"
+	ingressindexer "github.com/projectcapsule/capsule/pkg/indexer/ingress"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -84,117 +87,162 @@ func (r *collision) validate(ctx context.Context, client client.Client, req admi
 	return &response
 }
 
-//nolint:gocognit,gocyclo,cyclop
+// validateCollision returns an error if any of the routes of the Ingress is overlapping with the ones
+// of another Ingress, within the given scope: wildcard hostnames, as well as the path types, are taken into account.
 func (r *collision) validateCollision(ctx context.Context, clt client.Client, ing Ingress, scope api.HostnameCollisionScope) error {
-	for hostname, paths := range ing.HostnamePathsPairs() {
-		for path := range paths {
-			var ingressObjList client.ObjectList
-
-			switch ing.(type) {
-			case Extension:
-				ingressObjList = &extensionsv1beta1.IngressList{}
-			case NetworkingV1:
-				ingressObjList = &networkingv1.IngressList{}
-			case NetworkingV1Beta1:
-				ingressObjList = &networkingv1beta1.IngressList{}
-			}
-
-			namespaces := sets.NewString()
-			//nolint:exhaustive
-			switch scope {
-			case api.HostnameCollisionScopeCluster:
-				tenantList := &capsulev1beta2.TenantList{}
-				if err := clt.List(ctx, tenantList); err != nil {
-					return err
-				}
+	routes := ing.Routes()
+	if len(routes) == 0 {
+		return nil
+	}
 
-				for _, tenant := range tenantList.Items {
-					namespaces.Insert(tenant.Status.Namespaces...)
-				}
-			case api.HostnameCollisionScopeTenant:
-				selector := client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(".status.namespaces", ing.Namespace())}
+	namespaces, err := CollisionNamespaces(ctx, clt, ing.Namespace(), scope)
+	if err != nil {
+		return err
+	}
 
-				tenantList := &capsulev1beta2.TenantList{}
-				if err := clt.List(ctx, tenantList, selector); err != nil {
-					return err
-				}
+	others, err := overlappingIngresses(ctx, clt, ing, routes, namespaces)
+	if err != nil {
+		return err
+	}
 
-				for _, tenant := range tenantList.Items {
-					namespaces.Insert(tenant.Status.Namespaces...)
+	for _, other := range others {
+		for _, route := range routes {
+			for _, otherRoute := range other.Routes() {
+				if route.Overlaps(otherRoute) {
+					return NewIngressHostnameCollision(route, otherRoute, kindOf(other), other.Namespace(), other.Name())
 				}
-			case api.HostnameCollisionScopeNamespace:
-				namespaces.Insert(ing.Namespace())
 			}
+		}
+	}
+
+	return nil
+}
 
-			fieldSelector := fields.OneTermEqualSelector(ingress.HostPathPair, fmt.Sprintf("%s;%s", hostname, path))
+// CollisionNamespaces returns the Namespaces where the hostnames of the resources in the given Namespace must be unique,
+// according to the collision scope: the ones of all the Tenants, of the Tenant owning the Namespace, or the Namespace itself.
+func CollisionNamespaces(ctx context.Context, clt client.Reader, namespace string, scope api.HostnameCollisionScope) (sets.Set[string], error) {
+	namespaces := sets.New[string]()
 
-			if err := clt.List(ctx, ingressObjList, client.MatchingFieldsSelector{Selector: fieldSelector}); err != nil {
-				return err
+	tenantList := &capsulev1beta2.TenantList{}
+
+	//nolint:exhaustive
+	switch scope {
+	case api.HostnameCollisionScopeCluster:
+		if err := clt.List(ctx, tenantList); err != nil {
+			return nil, err
+		}
+	case api.HostnameCollisionScopeTenant:
+		selector := client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(".status.namespaces", namespace)}
+
+		if err := clt.List(ctx, tenantList, selector); err != nil {
+			return nil, err
+		}
+	case api.HostnameCollisionScopeNamespace:
+		namespaces.Insert(namespace)
+	}
+
+	for _, tenant := range tenantList.Items {
+		namespaces.Insert(tenant.Status.Namespaces...)
+	}
+
+	return namespaces, nil
+}
+
+// overlappingIngresses returns the Ingresses, of the same kind and API version of the given one, in the given Namespaces,
+// with a hostname overlapping the ones of the given routes: they're retrieved from the cache by hostname.
+func overlappingIngresses(ctx context.Context, clt client.Client, ing Ingress, routes []Route, namespaces sets.Set[string]) (ingresses []Ingress, err error) {
+	keys := sets.New[string]()
+
+	for _, route := range routes {
+		keys.Insert(ingressindexer.OverlappingHostnameKeys(route.Hostname)...)
+	}
+
+	found := sets.New[types.NamespacedName]()
+
+	for _, key := range sets.List(keys) {
+		var items []Ingress
+
+		if items, err = ingressesByHostnameKey(ctx, clt, ing, key); err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			name := types.NamespacedName{Namespace: item.Namespace(), Name: item.Name()}
+
+			if !namespaces.Has(name.Namespace) || found.Has(name) {
+				continue
 			}
 
-			ingressList := sets.NewInt()
+			if name.Namespace == ing.Namespace() && name.Name == ing.Name() {
+				continue
+			}
 
-			switch list := ingressObjList.(type) {
-			case *extensionsv1beta1.IngressList:
-				for index, item := range list.Items {
-					if namespaces.Has(item.GetNamespace()) {
-						ingressList.Insert(index)
-					}
-				}
+			found.Insert(name)
 
-				switch len(ingressList) {
-				case 0:
-					break
-				case 1:
-					if index := ingressList.List()[0]; list.Items[index].GetName() == ing.Name() && list.Items[index].GetNamespace() == ing.Namespace() {
-						break
-					}
-
-					fallthrough
-				default:
-					return NewIngressHostnameCollision(hostname)
-				}
-			case *networkingv1.IngressList:
-				for index, item := range list.Items {
-					if namespaces.Has(item.GetNamespace()) {
-						ingressList.Insert(index)
-					}
-				}
+			ingresses = append(ingresses, item)
+		}
+	}
 
-				switch len(ingressList) {
-				case 0:
-					break
-				case 1:
-					if index := ingressList.List()[0]; list.Items[index].GetName() == ing.Name() && list.Items[index].GetNamespace() == ing.Namespace() {
-						break
-					}
-
-					fallthrough
-				default:
-					return NewIngressHostnameCollision(hostname)
-				}
-			case *networkingv1beta1.IngressList:
-				for index, item := range list.Items {
-					if namespaces.Has(item.GetNamespace()) {
-						ingressList.Insert(index)
-					}
-				}
+	return ingresses, nil
+}
 
-				switch len(ingressList) {
-				case 0:
-					break
-				case 1:
-					if index := ingressList.List()[0]; list.Items[index].GetName() == ing.Name() && list.Items[index].GetNamespace() == ing.Namespace() {
-						break
-					}
-
-					fallthrough
-				default:
-					return NewIngressHostnameCollision(hostname)
-				}
+// ingressesByHostnameKey returns the Ingresses, of the same kind and API version of the given one, matching the given hostname index value.
+func ingressesByHostnameKey(ctx context.Context, clt client.Client, ing Ingress, key string) (ingresses []Ingress, err error) {
+	selector := client.MatchingFields{ingressindexer.HostnamesField: key}
+
+	switch ing.(type) {
+	case Extension:
+		list := &extensionsv1beta1.IngressList{}
+		if err = clt.List(ctx, list, selector); err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			ingresses = append(ingresses, Extension{Ingress: &list.Items[i]})
+		}
+	case NetworkingV1:
+		list := &networkingv1.IngressList{}
+		if err = clt.List(ctx, list, selector); err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			ingresses = append(ingresses, NetworkingV1{Ingress: &list.Items[i]})
+		}
+	case NetworkingV1Beta1:
+		list := &networkingv1beta1.IngressList{}
+		if err = clt.List(ctx, list, selector); err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			ingresses = append(ingresses, NetworkingV1Beta1{Ingress: &list.Items[i]})
+		}
+	case *OpenShiftRoute:
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: OpenShiftRouteGroup, Version: OpenShiftRouteVersion, Kind: OpenShiftRouteKind + "List"})
+
+		if err = clt.List(ctx, list, selector); err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			route := &OpenShiftRoute{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, route); err != nil {
+				return nil, err
 			}
+
+			ingresses = append(ingresses, route)
 		}
 	}
 
-	return nil
+	return ingresses, nil
+}
+
+func kindOf(ing Ingress) string {
+	if _, ok := ing.(*OpenShiftRoute); ok {
+		return OpenShiftRouteKind
+	}
+
+	return "Ingress"
 }
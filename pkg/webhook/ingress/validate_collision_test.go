@@ -0,0 +1,101 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	ingressindexer "github.com/projectcapsule/capsule/pkg/indexer/ingress"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+)
+
+func networkingV1Ingress(namespace, name, host, path string) NetworkingV1 {
+	pathType := networkingv1.PathTypePrefix
+
+	return NetworkingV1{Ingress: &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{Path: path, PathType: &pathType}},
+			}},
+		}}},
+	}}
+}
+
+func hostOnlyIngress(namespace, name, host string) NetworkingV1 {
+	return NetworkingV1{Ingress: &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: host}}},
+	}}
+}
+
+func TestValidateCollision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tenants := tenantindexer.NamespacesReference{Obj: &capsulev1beta2.Tenant{}}
+	hostnames := ingressindexer.Hostnames{Obj: &networkingv1.Ingress{}}
+
+	oil := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production", "oil-development"}},
+	}
+	gas := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "gas"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"gas-production"}},
+	}
+
+	wildcard := networkingV1Ingress("gas-production", "wildcard", "*.apps.acme.com", "/")
+	exact := networkingV1Ingress("oil-development", "exact", "API.acme.com", "/v1")
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(tenants.Object(), tenants.Field(), tenants.Func()).
+		WithIndex(hostnames.Object(), hostnames.Field(), hostnames.Func()).
+		WithObjects(oil, gas, wildcard.Ingress, exact.Ingress).
+		Build()
+
+	r := &collision{}
+
+	for _, tc := range []struct {
+		name      string
+		ing       Ingress
+		scope     api.HostnameCollisionScope
+		colliding bool
+	}{
+		{"exact hostname matched by a wildcard", networkingV1Ingress("oil-production", "web", "www.apps.acme.com", "/"), api.HostnameCollisionScopeCluster, true},
+		{"wildcard hostname matching an exact one", networkingV1Ingress("gas-production", "web", "*.acme.com", "/"), api.HostnameCollisionScopeCluster, true},
+		{"hostnames are case insensitive", networkingV1Ingress("oil-production", "web", "api.ACME.com", "/v1/users"), api.HostnameCollisionScopeTenant, true},
+		{"nested subdomain not matched by the wildcard", networkingV1Ingress("oil-production", "web", "www.dev.apps.acme.com", "/"), api.HostnameCollisionScopeCluster, false},
+		{"non overlapping paths", networkingV1Ingress("oil-production", "web", "api.acme.com", "/v2"), api.HostnameCollisionScopeCluster, false},
+		{"colliding Ingress out of the Tenant scope", networkingV1Ingress("oil-production", "web", "www.apps.acme.com", "/"), api.HostnameCollisionScopeTenant, false},
+		{"colliding Ingress out of the Namespace scope", networkingV1Ingress("oil-production", "web", "api.acme.com", "/v1"), api.HostnameCollisionScopeNamespace, false},
+		{"rule with no paths serving the whole hostname", hostOnlyIngress("oil-production", "web", "api.acme.com"), api.HostnameCollisionScopeCluster, true},
+		{"rule with no paths on another hostname", hostOnlyIngress("oil-production", "web", "www.acme.com"), api.HostnameCollisionScopeCluster, false},
+		{"the Ingress itself is ignored", networkingV1Ingress("oil-development", "exact", "api.acme.com", "/v1"), api.HostnameCollisionScopeCluster, false},
+	} {
+		err := r.validateCollision(context.Background(), c, tc.ing, tc.scope)
+		if !tc.colliding {
+			assert.NoError(t, err, tc.name)
+
+			continue
+		}
+
+		var collisionErr *ingressHostnameCollisionError
+
+		assert.ErrorAs(t, err, &collisionErr, tc.name)
+	}
+}
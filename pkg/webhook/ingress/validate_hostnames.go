@@ -6,6 +6,7 @@ package ingress
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -75,6 +76,15 @@ func (r *hostnames) validate(ctx context.Context, client client.Client, req admi
 		hostnameList.Insert(hostname)
 	}
 
+	// The hostnames shared with other Tenants are allowed, within the path prefixes assigned to the Tenant.
+	for _, shared := range tenant.Spec.IngressOptions.AllowedPathPrefixes {
+		hostnameList.Delete(strings.ToLower(shared.Hostname))
+	}
+
+	if hostnameList.Len() == 0 {
+		return nil
+	}
+
 	if err = r.validateHostnames(*tenant, hostnameList); err == nil {
 		return nil
 	}
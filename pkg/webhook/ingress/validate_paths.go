@@ -0,0 +1,212 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	ingressindexer "github.com/projectcapsule/capsule/pkg/indexer/ingress"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type paths struct{}
+
+func Paths() capsulewebhook.Handler {
+	return &paths{}
+}
+
+func (r *paths) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *paths) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *paths) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *paths) validate(ctx context.Context, client client.Client, req admission.Request, decoder admission.Decoder, recorder record.EventRecorder) *admission.Response {
+	ing, err := FromRequest(req, decoder)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tenant, err := TenantFromIngress(ctx, client, ing)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tenant == nil {
+		return nil
+	}
+
+	if err = validateReservedHostnames(ctx, client, ing, tenant); err != nil {
+		var reservedErr *ingressHostnameReservedError
+		if !errors.As(err, &reservedErr) {
+			return utils.ErroredResponse(err)
+		}
+
+		recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressHostnameReserved", "%s %s/%s hostname %s is reserved to other Tenants", kindOf(ing), ing.Namespace(), ing.Name(), reservedErr.hostname)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	if len(tenant.Spec.IngressOptions.AllowedPathPrefixes) == 0 {
+		return nil
+	}
+
+	if err = validatePathPrefixes(ing, tenant.Spec.IngressOptions.AllowedPathPrefixes); err != nil {
+		var notAllowedErr *ingressPathNotAllowedError
+		if !errors.As(err, &notAllowedErr) {
+			return utils.ErroredResponse(err)
+		}
+
+		recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressPathNotAllowed", "%s %s/%s path %q is not allowed on hostname %s", kindOf(ing), ing.Namespace(), ing.Name(), notAllowedErr.path, notAllowedErr.hostname)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateReservedHostnames returns an error for the first hostname overlapping one shared by other Tenants
+// through their allowed path prefixes: such hostnames are reserved to the Tenants declaring them.
+func validateReservedHostnames(ctx context.Context, c client.Reader, ing Ingress, tenant *capsulev1beta2.Tenant) error {
+	hostnames := make([]string, 0, len(ing.HostnamePathsPairs()))
+
+	for hostname := range ing.HostnamePathsPairs() {
+		if hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	sort.Strings(hostnames)
+
+	for _, hostname := range hostnames {
+		for _, key := range ingressindexer.OverlappingHostnameKeys(hostname) {
+			tntList := &capsulev1beta2.TenantList{}
+			if err := c.List(ctx, tntList, client.MatchingFields{ingressindexer.HostnamesField: key}); err != nil {
+				return err
+			}
+
+			for _, tnt := range tntList.Items {
+				if tnt.GetName() == tenant.GetName() {
+					continue
+				}
+
+				for _, shared := range tnt.Spec.IngressOptions.AllowedPathPrefixes {
+					if !hostnamesOverlap(hostname, shared.Hostname) || declaresHostname(tenant, shared.Hostname) {
+						continue
+					}
+
+					return NewIngressHostnameReserved(hostname, shared.Hostname)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func declaresHostname(tenant *capsulev1beta2.Tenant, hostname string) bool {
+	for _, shared := range tenant.Spec.IngressOptions.AllowedPathPrefixes {
+		if strings.EqualFold(shared.Hostname, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validatePathPrefixes returns an error for the first path, served on one of the given hostnames,
+// which is not within the allowed prefixes: only the Prefix and Exact path types are allowed,
+// since the matching of the ImplementationSpecific ones is up to the Ingress Controller.
+func validatePathPrefixes(ing Ingress, allowed []capsulev1beta2.HostnamePathPrefixes) error {
+	pairs := ing.HostnamePathsPairs()
+
+	hostnames := make([]string, 0, len(pairs))
+
+	for hostname := range pairs {
+		hostnames = append(hostnames, hostname)
+	}
+
+	sort.Strings(hostnames)
+
+	for _, hostname := range hostnames {
+		// A rule with no paths is serving the whole hostname.
+		if entries := pathPrefixesFor(hostname, allowed); len(entries) > 0 && pairs[hostname].Len() == 0 {
+			return NewIngressPathNotAllowed(hostname, "", entries[0].PathPrefixes)
+		}
+	}
+
+	routes := ing.Routes()
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Hostname < routes[j].Hostname || (routes[i].Hostname == routes[j].Hostname && routes[i].Path < routes[j].Path)
+	})
+
+	for _, route := range routes {
+		for _, entry := range pathPrefixesFor(route.Hostname, allowed) {
+			if !withinPathPrefixes(route.Path, entry.PathPrefixes) {
+				return NewIngressPathNotAllowed(route.Hostname, route.Path, entry.PathPrefixes)
+			}
+
+			if route.PathType != pathTypePrefix && route.PathType != pathTypeExact {
+				return NewIngressPathTypeNotAllowed(route.Hostname, route.Path, route.PathType, entry.PathPrefixes)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pathPrefixesFor returns the entries with a hostname overlapping the given one: a wildcard hostname,
+// such as *.example.com, is serving the paths of api.example.com too, and must be within its prefixes.
+func pathPrefixesFor(hostname string, allowed []capsulev1beta2.HostnamePathPrefixes) (entries []capsulev1beta2.HostnamePathPrefixes) {
+	for _, a := range allowed {
+		if hostnamesOverlap(a.Hostname, hostname) {
+			entries = append(entries, a)
+		}
+	}
+
+	return entries
+}
+
+func withinPathPrefixes(path string, prefixes []string) bool {
+	// An empty path, as for the rules with no paths, is serving the whole hostname.
+	if path == "" {
+		path = "/"
+	}
+
+	for _, prefix := range prefixes {
+		if api.IsPathPrefix(prefix, path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ingressindexer "github.com/projectcapsule/capsule/pkg/indexer/ingress"
+)
+
+func pathsIngress(host string, paths ...string) Ingress {
+	return pathTypeIngress(host, networkingv1.PathTypePrefix, paths...)
+}
+
+func pathTypeIngress(host string, pathType networkingv1.PathType, paths ...string) Ingress {
+	rule := networkingv1.IngressRule{Host: host}
+
+	if len(paths) > 0 {
+		rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+
+		for _, path := range paths {
+			rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1.HTTPIngressPath{Path: path, PathType: ptr.To(pathType)})
+		}
+	}
+
+	return NetworkingV1{Ingress: &networkingv1.Ingress{Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{rule}}}}
+}
+
+func TestValidatePathPrefixes(t *testing.T) {
+	allowed := []capsulev1beta2.HostnamePathPrefixes{{Hostname: "api.example.com", PathPrefixes: []string{"/payments", "/billing/"}}}
+
+	for name, tc := range map[string]struct {
+		ingress Ingress
+		valid   bool
+	}{
+		"within prefix":         {pathsIngress("api.example.com", "/payments", "/payments/v1", "/billing"), true},
+		"outside prefix":        {pathsIngress("api.example.com", "/payments", "/search"), false},
+		"not element-wise":      {pathsIngress("api.example.com", "/paymentsv2"), false},
+		"whole hostname":        {pathsIngress("api.example.com"), false},
+		"root path":             {pathsIngress("api.example.com", "/"), false},
+		"hostname not listed":   {pathsIngress("www.example.com", "/"), true},
+		"case insensitive host": {pathsIngress("API.example.com", "/search"), false},
+		"wildcard host":         {pathsIngress("*.example.com", "/search"), false},
+		"wildcard whole host":   {pathsIngress("*.example.com"), false},
+		"exact path type":       {pathTypeIngress("api.example.com", networkingv1.PathTypeExact, "/payments/v1"), true},
+		"implementation specific path type": {
+			pathTypeIngress("api.example.com", networkingv1.PathTypeImplementationSpecific, "/payments/.*"), false,
+		},
+		"implementation specific on a hostname not listed": {
+			pathTypeIngress("www.example.com", networkingv1.PathTypeImplementationSpecific, "/"), true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validatePathPrefixes(tc.ingress, allowed)
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+
+	// Distinct prefixes assigned to different Tenants on the same hostname are not colliding.
+	payments := Route{Hostname: "api.example.com", Path: "/payments", PathType: "Prefix"}
+	search := Route{Hostname: "api.example.com", Path: "/search", PathType: "Prefix"}
+	assert.False(t, payments.Overlaps(search))
+}
+
+func TestValidateReservedHostnames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	hostnames := ingressindexer.Hostnames{Obj: &capsulev1beta2.Tenant{}}
+
+	oil := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec: capsulev1beta2.TenantSpec{IngressOptions: capsulev1beta2.IngressOptions{
+			AllowedPathPrefixes: []capsulev1beta2.HostnamePathPrefixes{{Hostname: "api.example.com", PathPrefixes: []string{"/payments"}}},
+		}},
+	}
+	gas := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "gas"},
+		Spec: capsulev1beta2.TenantSpec{IngressOptions: capsulev1beta2.IngressOptions{
+			AllowedPathPrefixes: []capsulev1beta2.HostnamePathPrefixes{{Hostname: "api.example.com", PathPrefixes: []string{"/search"}}},
+		}},
+	}
+	water := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "water"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(hostnames.Object(), hostnames.Field(), hostnames.Func()).
+		WithObjects(oil, gas, water).
+		Build()
+
+	for name, tc := range map[string]struct {
+		tenant *capsulev1beta2.Tenant
+		ing    Ingress
+		valid  bool
+	}{
+		"declared by the Tenant":            {gas, pathsIngress("api.example.com", "/search"), true},
+		"not declared by the Tenant":        {water, pathsIngress("api.example.com", "/"), false},
+		"case insensitive":                  {water, pathsIngress("API.example.com", "/"), false},
+		"wildcard overlapping the hostname": {water, pathsIngress("*.example.com", "/"), false},
+		"hostname not shared":               {water, pathsIngress("www.example.com", "/"), true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateReservedHostnames(context.Background(), c, tc.ing, tc.tenant)
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+}
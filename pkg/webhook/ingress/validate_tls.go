@@ -0,0 +1,172 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type tls struct{}
+
+func TLS() capsulewebhook.Handler {
+	return &tls{}
+}
+
+func (r *tls) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *tls) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.validate(ctx, client, req, decoder, recorder)
+	}
+}
+
+func (r *tls) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *tls) validate(ctx context.Context, c client.Client, req admission.Request, decoder admission.Decoder, recorder record.EventRecorder) *admission.Response {
+	ing, err := FromRequest(req, decoder)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := TenantFromIngress(ctx, c, ing)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.IngressOptions.TLS == nil {
+		return nil
+	}
+
+	options := tnt.Spec.IngressOptions.TLS
+
+	if err = validateTLS(ing, *options); err == nil && options.RequireExistingSecret {
+		err = validateTLSSecrets(ctx, c, ing)
+	}
+
+	if err != nil {
+		var notValid *ingressTLSNotValidError
+		if !errors.As(err, &notValid) {
+			return utils.ErroredResponse(err)
+		}
+
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "IngressTLSNotValid", "Ingress %s/%s TLS is not valid: %s", ing.Namespace(), ing.Name(), notValid.reason)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateTLS checks the TLS blocks of the Ingress against the TLS requirements of the Tenant,
+// apart from the existence of the referenced Secrets.
+func validateTLS(ing Ingress, options capsulev1beta2.IngressTLSOptions) error {
+	blocks := ing.TLS()
+
+	if options.Required && len(blocks) == 0 {
+		return NewIngressTLSNotValid("at least a TLS block is required")
+	}
+
+	if !options.EnforceHostsMatch {
+		return nil
+	}
+
+	ruleHosts := make([]string, 0, len(ing.HostnamePathsPairs()))
+
+	for hostname := range ing.HostnamePathsPairs() {
+		ruleHosts = append(ruleHosts, hostname)
+	}
+
+	sort.Strings(ruleHosts)
+
+	tlsHosts := make([]string, 0)
+
+	for _, block := range blocks {
+		tlsHosts = append(tlsHosts, block.Hosts...)
+	}
+
+	for _, hostname := range ruleHosts {
+		// Rules with no host are matching any request, thus they can't be covered by a TLS block.
+		if hostname == "" {
+			continue
+		}
+
+		if !coveredByTLS(hostname, tlsHosts) {
+			return NewIngressTLSNotValid("rule host " + hostname + " is not covered by any TLS block")
+		}
+	}
+
+	for _, hostname := range tlsHosts {
+		used := false
+
+		for _, ruleHost := range ruleHosts {
+			if used = hostnamesOverlap(hostname, ruleHost); used {
+				break
+			}
+		}
+
+		if !used {
+			return NewIngressTLSNotValid("TLS host " + hostname + " is not used by any rule")
+		}
+	}
+
+	return nil
+}
+
+// coveredByTLS returns true if the hostname is equal to, or matched by, one of the given ones.
+func coveredByTLS(hostname string, hosts []string) bool {
+	for _, host := range hosts {
+		if host, hostname = strings.ToLower(host), strings.ToLower(hostname); host == hostname || matchesWildcard(host, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateTLSSecrets(ctx context.Context, c client.Client, ing Ingress) error {
+	for _, block := range ing.TLS() {
+		// An empty Secret name means the Ingress Controller default certificate is used.
+		if block.SecretName == "" {
+			continue
+		}
+
+		secret := &metav1.PartialObjectMetadata{}
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ing.Namespace(), Name: block.SecretName}, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return NewIngressTLSNotValid("Secret " + block.SecretName + " does not exist in the Namespace " + ing.Namespace())
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func tlsIngress(hosts []string, tls ...networkingv1.IngressTLS) Ingress {
+	ing := &networkingv1.Ingress{}
+
+	for _, host := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{Host: host})
+	}
+
+	ing.Spec.TLS = tls
+
+	return NetworkingV1{Ingress: ing}
+}
+
+func TestValidateTLS(t *testing.T) {
+	required := capsulev1beta2.IngressTLSOptions{Required: true}
+	match := capsulev1beta2.IngressTLSOptions{EnforceHostsMatch: true}
+
+	for name, tc := range map[string]struct {
+		ingress Ingress
+		options capsulev1beta2.IngressTLSOptions
+		valid   bool
+	}{
+		"missing TLS":                {tlsIngress([]string{"foo.acme.com"}), required, false},
+		"hosts match, missing TLS":   {tlsIngress([]string{"foo.acme.com"}), match, false},
+		"hosts match, no rule hosts": {tlsIngress(nil), match, true},
+		"matching hosts": {
+			tlsIngress([]string{"foo.acme.com"}, networkingv1.IngressTLS{Hosts: []string{"foo.acme.com"}, SecretName: "foo"}),
+			match, true,
+		},
+		"wildcard TLS host": {
+			tlsIngress([]string{"foo.acme.com", "bar.acme.com"}, networkingv1.IngressTLS{Hosts: []string{"*.acme.com"}}),
+			match, true,
+		},
+		"uncovered rule host": {
+			tlsIngress([]string{"foo.acme.com", "bar.acme.com"}, networkingv1.IngressTLS{Hosts: []string{"foo.acme.com"}}),
+			match, false,
+		},
+		"unused TLS host": {
+			tlsIngress([]string{"foo.acme.com"}, networkingv1.IngressTLS{Hosts: []string{"foo.acme.com", "bar.acme.com"}}),
+			match, false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateTLS(tc.ingress, tc.options)
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	forbidden := api.ForbiddenListSpec{Regex: `^nginx\.ingress\.kubernetes\.io/.*-snippet$`}
+	allowed := &api.AllowedListSpec{Exact: []string{"cert-manager.io/cluster-issuer"}, Regex: `^nginx\.ingress\.kubernetes\.io/`}
+
+	assert.NoError(t, validateAnnotations(map[string]string{"foo": "bar"}, forbidden, nil))
+	assert.Error(t, validateAnnotations(map[string]string{"nginx.ingress.kubernetes.io/server-snippet": ""}, forbidden, nil))
+	assert.NoError(t, validateAnnotations(map[string]string{"cert-manager.io/cluster-issuer": "", "nginx.ingress.kubernetes.io/rewrite-target": "/"}, forbidden, allowed))
+	assert.Error(t, validateAnnotations(map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": ""}, forbidden, allowed))
+	assert.Error(t, validateAnnotations(map[string]string{"foo": "bar"}, forbidden, allowed))
+	// The annotations managed by kubectl, and Capsule, are not subject to the allowed ones.
+	assert.NoError(t, validateAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}", "capsule.clastix.io/managed-by": "oil"}, forbidden, allowed))
+	assert.Error(t, validateAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"}, api.ForbiddenListSpec{Exact: []string{"kubectl.kubernetes.io/last-applied-configuration"}}, allowed))
+}
@@ -5,10 +5,12 @@ package namespace
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -46,6 +48,20 @@ func (r *userMetadataHandler) OnCreate(client client.Client, decoder admission.D
 			}
 		}
 
+		if tnt.Spec.PodOptions != nil && tnt.Spec.PodOptions.PodSecurityAdmission != nil {
+			enforced := tnt.Spec.PodOptions.PodSecurityAdmission.Labels()
+
+			for _, key := range sets.List(sets.KeySet(enforced)) {
+				if v, ok := ns.GetLabels()[key]; ok && v != enforced[key] {
+					response := admission.Denied(fmt.Sprintf("the %s label is enforced by the Tenant to %s, cannot be set to %s", key, enforced[key], v))
+
+					recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPodSecurityLabel", string(response.Result.Reason))
+
+					return &response
+				}
+			}
+		}
+
 		if tnt.Spec.NamespaceOptions != nil {
 			err := api.ValidateForbidden(ns.ObjectMeta.Annotations, tnt.Spec.NamespaceOptions.ForbiddenAnnotations)
 			if err != nil {
@@ -120,6 +136,18 @@ func (r *userMetadataHandler) OnUpdate(client client.Client, decoder admission.D
 			}
 		}
 
+		if tnt.Spec.PodOptions != nil && tnt.Spec.PodOptions.PodSecurityAdmission != nil {
+			for key := range tnt.Spec.PodOptions.PodSecurityAdmission.Labels() {
+				if newNs.GetLabels()[key] != oldNs.GetLabels()[key] {
+					response := admission.Denied(fmt.Sprintf("the %s label is enforced by the Tenant, cannot be updated", key))
+
+					recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPodSecurityLabelUpdate", string(response.Result.Reason))
+
+					return &response
+				}
+			}
+		}
+
 		labels, annotations := oldNs.GetLabels(), oldNs.GetAnnotations()
 
 		if labels == nil {
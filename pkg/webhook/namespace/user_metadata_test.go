@@ -0,0 +1,77 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestUserMetadataPodSecurityLabelsOnCreate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := &capsulev1beta2.Tenant{
+		TypeMeta:   metav1.TypeMeta{APIVersion: capsulev1beta2.GroupVersion.String(), Kind: "Tenant"},
+		ObjectMeta: metav1.ObjectMeta{Name: "oil", UID: "oil"},
+		Spec: capsulev1beta2.TenantSpec{PodOptions: &api.PodOptions{
+			PodSecurityAdmission: &api.PodSecurityAdmissionSpec{Enforce: "restricted"},
+		}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tnt).Build()
+
+	request := func(labels map[string]string) admission.Request {
+		data, err := json.Marshal(&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "oil-production",
+				Labels: labels,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: tnt.APIVersion, Kind: tnt.Kind, Name: tnt.Name, UID: tnt.UID, Controller: ptr.To(true),
+				}},
+			},
+		})
+		assert.NoError(t, err)
+
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+			Name:      "oil-production",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: data},
+		}}
+	}
+
+	validate := UserMetadataHandler().OnCreate(c, admission.NewDecoder(scheme), record.NewFakeRecorder(10))
+
+	for name, tc := range map[string]struct {
+		labels  map[string]string
+		allowed bool
+	}{
+		"no Pod Security labels":          {nil, true},
+		"enforced level":                  {map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}, true},
+		"conflicting level":               {map[string]string{"pod-security.kubernetes.io/enforce": "privileged"}, false},
+		"conflicting version":             {map[string]string{"pod-security.kubernetes.io/enforce-version": "v1.24"}, false},
+		"mode not enforced by the Tenant": {map[string]string{"pod-security.kubernetes.io/audit": "privileged"}, true},
+	} {
+		response := validate(context.Background(), request(tc.labels))
+		assert.Equal(t, tc.allowed, response == nil, name)
+	}
+}
@@ -35,22 +35,30 @@ func (h *containerRegistryHandler) OnDelete(client.Client, admission.Decoder, re
 	}
 }
 
-// ust be validate on update events since updates to pods on spec.containers[*].image and spec.initContainers[*].image are allowed.
+// Must be validated on update events since updates to pods on spec.containers[*].image, spec.initContainers[*].image,
+// and spec.ephemeralContainers[*].image are allowed, as well as to the Pod templates of workload controllers.
 func (h *containerRegistryHandler) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
 		return h.validate(ctx, c, decoder, recorder, req)
 	}
 }
 
 func (h *containerRegistryHandler) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
-	pod := &corev1.Pod{}
-	if err := decoder.Decode(req, pod); err != nil {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
 		return utils.ErroredResponse(err)
 	}
 
 	tntList := &capsulev1beta2.TenantList{}
 	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
-		Selector: fields.OneTermEqualSelector(".status.namespaces", pod.Namespace),
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
 	}); err != nil {
 		return utils.ErroredResponse(err)
 	}
@@ -62,16 +70,9 @@ func (h *containerRegistryHandler) validate(ctx context.Context, c client.Client
 	tnt := tntList.Items[0]
 
 	if tnt.Spec.ContainerRegistries != nil {
-		// Evaluate init containers
-		for _, container := range pod.Spec.InitContainers {
-			if response := h.VerifyContainerRegistry(recorder, req, container, tnt); response != nil {
-				return response
-			}
-		}
-
-		// Evaluate containers
-		for _, container := range pod.Spec.Containers {
-			if response := h.VerifyContainerRegistry(recorder, req, container, tnt); response != nil {
+		// Evaluate init, ephemeral, and regular containers
+		for _, container := range containers(spec) {
+			if response := h.VerifyContainerRegistry(recorder, req, container.Image, tnt); response != nil {
 				return response
 			}
 		}
@@ -80,15 +81,15 @@ func (h *containerRegistryHandler) validate(ctx context.Context, c client.Client
 	return nil
 }
 
-func (h *containerRegistryHandler) VerifyContainerRegistry(recorder record.EventRecorder, req admission.Request, container corev1.Container, tnt capsulev1beta2.Tenant) *admission.Response {
+func (h *containerRegistryHandler) VerifyContainerRegistry(recorder record.EventRecorder, req admission.Request, image string, tnt capsulev1beta2.Tenant) *admission.Response {
 	var valid, matched bool
 
-	reg := NewRegistry(container.Image)
+	reg := NewRegistry(image)
 
 	if len(reg.Registry()) == 0 {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingFQCI", "Pod %s/%s is not using a fully qualified container image, cannot enforce registry the current Tenant", req.Namespace, req.Name, reg.Registry())
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingFQCI", "%s %s/%s is not using a fully qualified container image, cannot enforce registry the current Tenant", req.Kind.Kind, req.Namespace, req.Name)
 
-		response := admission.Denied(NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
+		response := admission.Denied(NewContainerRegistryForbidden(image, *tnt.Spec.ContainerRegistries).Error())
 
 		return &response
 	}
@@ -98,9 +99,9 @@ func (h *containerRegistryHandler) VerifyContainerRegistry(recorder record.Event
 	matched = tnt.Spec.ContainerRegistries.RegexMatch(reg.Registry())
 
 	if !valid && !matched {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "Pod %s/%s is using a container hosted on registry %s that is forbidden for the current Tenant", req.Namespace, req.Name, reg.Registry())
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "%s %s/%s is using a container hosted on registry %s that is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, reg.Registry())
 
-		response := admission.Denied(NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
+		response := admission.Denied(NewContainerRegistryForbidden(image, *tnt.Spec.ContainerRegistries).Error())
 
 		return &response
 	}
@@ -4,7 +4,7 @@
 package pod
 
 import (
-	"regexp"
+	"strings"
 )
 
 type registry map[string]string
@@ -49,15 +49,19 @@ func (r registry) Tag() string {
 	return res
 }
 
+// NewRegistry returns the components of the given container image as written: unlike NewImageRef, the registry is
+// the first component of the path, if any, with no default, since the allowed registries require fully qualified images.
 func NewRegistry(value string) Registry {
-	reg := make(registry)
-	r := regexp.MustCompile(`((?P<registry>[a-zA-Z0-9-._]+(:\d+)?)\/)?(?P<repository>.*\/)?(?P<image>[a-zA-Z0-9-._]+:(?P<tag>[a-zA-Z0-9-._]+))?`)
-	match := r.FindStringSubmatch(value)
-
-	for i, name := range r.SubexpNames() {
-		if i > 0 && i <= len(match) {
-			reg[name] = match[i]
-		}
+	domain, path, tag, _ := splitImage(value)
+
+	reg := registry{"registry": domain, "tag": tag}
+
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		reg["repository"], path = path[:i+1], path[i+1:]
+	}
+
+	if len(tag) > 0 {
+		reg["image"] = path + ":" + tag
 	}
 
 	return reg
@@ -7,72 +7,96 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/configuration"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
 
-type imagePullPolicy struct{}
+type imagePullPolicy struct {
+	configuration configuration.Configuration
+}
 
-func ImagePullPolicy() capsulewebhook.Handler {
-	return &imagePullPolicy{}
+func ImagePullPolicy(configuration configuration.Configuration) capsulewebhook.Handler {
+	return &imagePullPolicy{configuration: configuration}
 }
 
 func (r *imagePullPolicy) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		pod := &corev1.Pod{}
-		if err := decoder.Decode(req, pod); err != nil {
-			return utils.ErroredResponse(err)
-		}
+		return r.validate(ctx, c, decoder, recorder, req)
+	}
+}
 
-		tntList := &capsulev1beta2.TenantList{}
-		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector(".status.namespaces", pod.Namespace),
-		}); err != nil {
+func (r *imagePullPolicy) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
 			return utils.ErroredResponse(err)
-		}
-		// the Pod is not running in a Namespace managed by a Tenant
-		if len(tntList.Items) == 0 {
+		case !changed:
 			return nil
 		}
 
-		tnt := tntList.Items[0]
+		return r.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (r *imagePullPolicy) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
 
-		policy := NewPullPolicy(&tnt)
-		// if Tenant doesn't enforce the pull policy, exit
-		if policy == nil {
-			return nil
-		}
+func (r *imagePullPolicy) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	meta, spec, err := podTemplate(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
 
-		for _, container := range pod.Spec.Containers {
-			usedPullPolicy := string(container.ImagePullPolicy)
+	tntList := &capsulev1beta2.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+	// the Pod is not running in a Namespace managed by a Tenant
+	if len(tntList.Items) == 0 {
+		return nil
+	}
 
-			if !policy.IsPolicySupported(usedPullPolicy) {
-				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenPullPolicy", "Pod %s/%s pull policy %s is forbidden for the current Tenant", req.Namespace, req.Name, usedPullPolicy)
+	tnt := tntList.Items[0]
 
-				response := admission.Denied(NewImagePullPolicyForbidden(usedPullPolicy, container.Name, policy.AllowedPullPolicies()).Error())
+	if err = validatePullPolicies(&tnt, meta, spec, r.configuration.RegistryMirrors()); err != nil {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenPullPolicy", "%s %s/%s pull policy is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name)
 
-				return &response
-			}
-		}
+		response := admission.Denied(err.Error())
 
-		return nil
+		return &response
 	}
-}
 
-func (r *imagePullPolicy) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
-	return func(context.Context, admission.Request) *admission.Response {
-		return nil
-	}
+	return nil
 }
 
-func (r *imagePullPolicy) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
-	return func(context.Context, admission.Request) *admission.Response {
-		return nil
+// validatePullPolicies returns an error for the first container using a pull policy not allowed by the Tenant:
+// the rules are matching the image as requested, rather than the registry mirror it has been rewritten to.
+func validatePullPolicies(tnt *capsulev1beta2.Tenant, meta *metav1.ObjectMeta, spec *corev1.PodSpec, globalMirrors []api.RegistryMirrorSpec) error {
+	for _, container := range containers(spec) {
+		policy := NewPullPolicy(tnt, SourceImage(meta.GetAnnotations(), container.Name, container.Image, tnt.Spec.RegistryMirrors, globalMirrors))
+		// if Tenant doesn't enforce the pull policy for the image, skip
+		if policy == nil {
+			continue
+		}
+
+		if usedPullPolicy := string(container.ImagePullPolicy); !policy.IsPolicySupported(usedPullPolicy) {
+			return NewImagePullPolicyForbidden(usedPullPolicy, container.Name, policy.AllowedPullPolicies())
+		}
 	}
+
+	return nil
 }
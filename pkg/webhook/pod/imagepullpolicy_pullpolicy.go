@@ -4,9 +4,11 @@
 package pod
 
 import (
+	"encoding/json"
 	"strings"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
 )
 
 type PullPolicy interface {
@@ -32,15 +34,22 @@ func (i imagePullPolicyValidator) AllowedPullPolicies() []string {
 	return i.allowedPolicies
 }
 
-func NewPullPolicy(tenant *capsulev1beta2.Tenant) PullPolicy {
+// NewPullPolicy returns the pull policy enforced by the Tenant for the given container image:
+// the first matching rule takes precedence over the allowed image pull policies.
+func NewPullPolicy(tenant *capsulev1beta2.Tenant, image string) PullPolicy {
+	policies := tenant.Spec.ImagePullPolicies
+
+	if rule := ImagePullPolicyRuleFor(image, tenant.Spec.ImagePullPolicyRules); rule != nil {
+		policies = rule.AllowedPolicies
+	}
 	// the Tenant doesn't enforce the allowed image pull policy, returning nil
-	if len(tenant.Spec.ImagePullPolicies) == 0 {
+	if len(policies) == 0 {
 		return nil
 	}
 
-	allowedPolicies := make([]string, 0, len(tenant.Spec.ImagePullPolicies))
+	allowedPolicies := make([]string, 0, len(policies))
 
-	for _, policy := range tenant.Spec.ImagePullPolicies {
+	for _, policy := range policies {
 		allowedPolicies = append(allowedPolicies, policy.String())
 	}
 
@@ -48,3 +57,62 @@ func NewPullPolicy(tenant *capsulev1beta2.Tenant) PullPolicy {
 		allowedPolicies: allowedPolicies,
 	}
 }
+
+// ImagePullPolicyRuleFor returns the first rule matching the given container image, if any.
+func ImagePullPolicyRuleFor(image string, rules []api.ImagePullPolicyRule) *api.ImagePullPolicyRule {
+	ref := NewImageRef(image)
+
+	for i, rule := range rules {
+		if !strings.Contains(rule.Pattern, "/") {
+			if ref.Registry == rule.Pattern {
+				return &rules[i]
+			}
+
+			continue
+		}
+
+		if ref.MatchRepository(rule.Pattern) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+// SourceImage returns the image of the given container before being rewritten to a registry mirror, as recorded
+// in the given annotations, or the container image itself. Since the annotations are set by the Tenant owners too,
+// the recorded image is trusted only if the container image is its rewrite to the registry mirror, looked up in the given lists.
+func SourceImage(annotations map[string]string, name, image string, mirrors ...[]api.RegistryMirrorSpec) string {
+	original, ok := OriginalImages(annotations)[name]
+	if !ok || !IsMirroredImage(image, original, mirrors...) {
+		return image
+	}
+
+	return original
+}
+
+// OriginalImages returns the images recorded before the registry mirrors rewrite, keyed by container name.
+func OriginalImages(annotations map[string]string) map[string]string {
+	recorded := map[string]string{}
+
+	if value, ok := annotations[api.OriginalImagesAnnotation]; ok && json.Unmarshal([]byte(value), &recorded) != nil {
+		return map[string]string{}
+	}
+
+	return recorded
+}
+
+// IsMirroredImage returns true if the given image is the original one rewritten to the mirror of its registry:
+// the mirror is rewriting the registry only, keeping the repository, the tag, and the digest.
+func IsMirroredImage(image, original string, mirrors ...[]api.RegistryMirrorSpec) bool {
+	ref := NewImageRef(original)
+
+	mirror, ok := api.MirrorFor(ref.Registry, mirrors...)
+	if !ok {
+		return false
+	}
+
+	ref.Registry = mirror
+
+	return ref.String() == image
+}
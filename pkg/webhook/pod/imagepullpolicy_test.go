@@ -0,0 +1,73 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestNewPullPolicy(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{
+		ImagePullPolicies: []api.ImagePullPolicySpec{"Always", "IfNotPresent"},
+		ImagePullPolicyRules: []api.ImagePullPolicyRule{
+			{Pattern: "registry.corp/base/*", AllowedPolicies: []api.ImagePullPolicySpec{"IfNotPresent"}},
+			{Pattern: "registry.corp", AllowedPolicies: []api.ImagePullPolicySpec{"Always"}},
+		},
+	}}
+
+	for image, allowed := range map[string][]string{
+		"registry.corp/base/debian:12":  {"IfNotPresent"},
+		"registry.corp/team-a/app:main": {"Always"},
+		"quay.io/org/app:v1":            {"Always", "IfNotPresent"},
+		"busybox":                       {"Always", "IfNotPresent"},
+	} {
+		assert.Equal(t, allowed, NewPullPolicy(tnt, image).AllowedPullPolicies(), image)
+	}
+
+	tnt.Spec.ImagePullPolicies = nil
+
+	assert.Nil(t, NewPullPolicy(tnt, "quay.io/org/app:v1"))
+	assert.True(t, NewPullPolicy(tnt, "registry.corp/team-a/app:main").IsPolicySupported("Always"))
+	assert.False(t, NewPullPolicy(tnt, "registry.corp/team-a/app:main").IsPolicySupported("IfNotPresent"))
+}
+
+func TestSourceImage(t *testing.T) {
+	annotations := map[string]string{api.OriginalImagesAnnotation: `{"app":"busybox:1.36","sidecar":"quay.io/org/sidecar:v1"}`}
+	mirrors := []api.RegistryMirrorSpec{{Registry: "docker.io", Mirror: "mirror.corp/dockerhub"}, {Registry: "quay.io", Mirror: "mirror.corp/quay"}}
+
+	assert.Equal(t, "busybox:1.36", SourceImage(annotations, "app", "mirror.corp/dockerhub/library/busybox:1.36", mirrors))
+	assert.Equal(t, "quay.io/org/sidecar:v1", SourceImage(annotations, "sidecar", "mirror.corp/quay/org/sidecar:v1", mirrors))
+	// The recorded image is ignored once the container image has been changed.
+	assert.Equal(t, "mirror.corp/quay/org/sidecar:v2", SourceImage(annotations, "sidecar", "mirror.corp/quay/org/sidecar:v2", mirrors))
+	// The recorded image is ignored if the container image is not served by its registry mirror.
+	assert.Equal(t, "mirror.corp/quay/org/sidecar:v1", SourceImage(annotations, "sidecar", "mirror.corp/quay/org/sidecar:v1"))
+	assert.Equal(t, "nginx", SourceImage(annotations, "web", "nginx", mirrors))
+	assert.Equal(t, "nginx", SourceImage(nil, "app", "nginx", mirrors))
+}
+
+func TestValidatePullPoliciesForgedOriginalImages(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{
+		ImagePullPolicyRules: []api.ImagePullPolicyRule{
+			{Pattern: "registry.corp", AllowedPolicies: []api.ImagePullPolicySpec{"Always"}},
+		},
+		RegistryMirrors: []api.RegistryMirrorSpec{{Registry: "docker.io", Mirror: "mirror.corp/dockerhub"}},
+	}}
+
+	spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "registry.corp/team/app:v1", ImagePullPolicy: corev1.PullIfNotPresent}}}
+	// The annotation is claiming an image from a registry not covered by any rule, nor rewritten to registry.corp.
+	forged := &metav1.ObjectMeta{Annotations: map[string]string{api.OriginalImagesAnnotation: `{"app":"quay.io/team/app:v1"}`}}
+
+	assert.Error(t, validatePullPolicies(tnt, forged, spec, nil))
+
+	spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+
+	assert.NoError(t, validatePullPolicies(tnt, forged, spec, nil))
+}
@@ -0,0 +1,112 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type imageReference struct{}
+
+func ImageReference() capsulewebhook.Handler {
+	return &imageReference{}
+}
+
+func (h *imageReference) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *imageReference) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// Must be validated on update events since updates to the container images are allowed.
+func (h *imageReference) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *imageReference) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.ImageReferences == nil {
+		return nil
+	}
+
+	for _, container := range containers(spec) {
+		if err := verifyImageReference(container.Image, *tnt.Spec.ImageReferences); err != nil {
+			recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenImageReference", "%s %s/%s is using the container image %s that is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, container.Image)
+
+			response := admission.Denied(err.Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
+
+// verifyImageReference returns the error describing the first rule the given container image is not satisfying.
+func verifyImageReference(image string, spec api.ImageReferenceSpec) error {
+	ref := NewImageRef(image)
+
+	switch {
+	case spec.ForbidUntagged && ref.IsUntagged():
+		return NewImageUntaggedForbidden(image)
+	case spec.ForbidLatestTag && ref.IsLatest():
+		return NewImageLatestTagForbidden(image)
+	case spec.DigestRequiredRegistries != nil && spec.DigestRequiredRegistries.Match(ref.Registry) && len(ref.Digest) == 0:
+		return NewImageDigestRequired(image, ref.Registry)
+	}
+
+	var restricted []string
+
+	for _, pattern := range spec.AllowedRepositories {
+		if !strings.HasPrefix(pattern, ref.Registry+"/") {
+			continue
+		}
+
+		if ref.MatchRepository(pattern) {
+			return nil
+		}
+
+		restricted = append(restricted, pattern)
+	}
+
+	if len(restricted) > 0 {
+		return NewImageRepositoryForbidden(image, restricted)
+	}
+
+	return nil
+}
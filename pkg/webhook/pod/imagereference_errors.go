@@ -0,0 +1,59 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+	"strings"
+)
+
+type imageLatestTagForbiddenError struct {
+	image string
+}
+
+func NewImageLatestTagForbidden(image string) error {
+	return &imageLatestTagForbiddenError{image: image}
+}
+
+func (f imageLatestTagForbiddenError) Error() string {
+	return fmt.Sprintf("Container image %s is using the latest tag that is forbidden for the current Tenant, please, use an immutable tag or a digest", f.image)
+}
+
+type imageUntaggedForbiddenError struct {
+	image string
+}
+
+func NewImageUntaggedForbidden(image string) error {
+	return &imageUntaggedForbiddenError{image: image}
+}
+
+func (f imageUntaggedForbiddenError) Error() string {
+	return fmt.Sprintf("Container image %s is untagged that is forbidden for the current Tenant, please, specify a tag or a digest", f.image)
+}
+
+type imageDigestRequiredError struct {
+	image    string
+	registry string
+}
+
+func NewImageDigestRequired(image, registry string) error {
+	return &imageDigestRequiredError{image: image, registry: registry}
+}
+
+func (f imageDigestRequiredError) Error() string {
+	return fmt.Sprintf("Container image %s must be referenced by digest, as required by the current Tenant for the registry %s", f.image, f.registry)
+}
+
+type imageRepositoryForbiddenError struct {
+	image   string
+	allowed []string
+}
+
+func NewImageRepositoryForbidden(image string, allowed []string) error {
+	return &imageRepositoryForbiddenError{image: image, allowed: allowed}
+}
+
+func (f imageRepositoryForbiddenError) Error() string {
+	return fmt.Sprintf("Container image %s repository is forbidden for the current Tenant: use one matching the following list (%s)", f.image, strings.Join(f.allowed, ", "))
+}
@@ -0,0 +1,100 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"strings"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const defaultRegistry = "docker.io"
+
+// ImageRef is a container image reference, split in its components.
+type ImageRef struct {
+	// Registry hosting the image: docker.io when not specified.
+	Registry string
+	// Repository path within the registry, such as library/busybox.
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// NewImageRef parses the given container image, following the same normalization rules of the container runtimes.
+func NewImageRef(image string) ImageRef {
+	domain, path, tag, digest := splitImage(image)
+
+	ref := ImageRef{Tag: tag, Digest: digest}
+
+	switch {
+	case strings.ContainsAny(domain, ".:") || domain == "localhost":
+		ref.Registry, ref.Repository = api.NormalizeRegistry(domain), path
+		// Docker Hub official images are stored in the library namespace, regardless of the registry alias.
+		if ref.Registry == defaultRegistry && !strings.Contains(ref.Repository, "/") {
+			ref.Repository = "library/" + ref.Repository
+		}
+	case len(domain) > 0:
+		ref.Registry, ref.Repository = defaultRegistry, domain+"/"+path
+	default:
+		ref.Registry, ref.Repository = defaultRegistry, "library/"+path
+	}
+
+	return ref
+}
+
+// splitImage splits the given container image in its components, as written, with no normalization:
+// the domain is the first component of the path, if any, regardless of being a registry host.
+func splitImage(image string) (domain, path, tag, digest string) {
+	path = image
+
+	if i := strings.Index(path, "@"); i >= 0 {
+		digest, path = path[i+1:], path[:i]
+	}
+
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		tag, path = path[i+1:], path[:i]
+	}
+
+	if i := strings.Index(path, "/"); i >= 0 {
+		domain, path = path[:i], path[i+1:]
+	}
+
+	return domain, path, tag, digest
+}
+
+// IsUntagged returns true when the reference specifies neither a tag, nor a digest.
+func (r ImageRef) IsUntagged() bool {
+	return len(r.Tag) == 0 && len(r.Digest) == 0
+}
+
+// IsLatest returns true when the reference resolves to the latest tag, and it's not pinned by digest.
+func (r ImageRef) IsLatest() bool {
+	return len(r.Digest) == 0 && (len(r.Tag) == 0 || r.Tag == "latest")
+}
+
+// MatchRepository returns true if the reference is matching the given repository pattern, such as registry.corp/team-a/*.
+func (r ImageRef) MatchRepository(pattern string) bool {
+	name := r.Registry + "/" + r.Repository
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+
+	return name == pattern
+}
+
+// String returns the fully qualified reference.
+func (r ImageRef) String() string {
+	image := r.Registry + "/" + r.Repository
+
+	if len(r.Tag) > 0 {
+		image += ":" + r.Tag
+	}
+
+	if len(r.Digest) > 0 {
+		image += "@" + r.Digest
+	}
+
+	return image
+}
@@ -0,0 +1,72 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestNewImageRef(t *testing.T) {
+	for image, expected := range map[string]ImageRef{
+		"busybox":                          {Registry: "docker.io", Repository: "library/busybox"},
+		"busybox:1.36":                     {Registry: "docker.io", Repository: "library/busybox", Tag: "1.36"},
+		"bitnami/nginx:latest":             {Registry: "docker.io", Repository: "bitnami/nginx", Tag: "latest"},
+		"index.docker.io/busybox:1.36":     {Registry: "docker.io", Repository: "library/busybox", Tag: "1.36"},
+		"index.docker.io/bitnami/nginx":    {Registry: "docker.io", Repository: "bitnami/nginx"},
+		"quay.io/org/app@sha256:abc":       {Registry: "quay.io", Repository: "org/app", Digest: "sha256:abc"},
+		"registry.corp:5000/team-a/app:v1": {Registry: "registry.corp:5000", Repository: "team-a/app", Tag: "v1"},
+		"localhost/app:v1@sha256:abc":      {Registry: "localhost", Repository: "app", Tag: "v1", Digest: "sha256:abc"},
+	} {
+		assert.Equal(t, expected, NewImageRef(image), image)
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	for image, expected := range map[string][4]string{
+		"busybox":                              {"", "", "", "latest"},
+		"busybox:1.36":                         {"", "", "busybox:1.36", "1.36"},
+		"bitnami/nginx:1.27":                   {"bitnami", "", "nginx:1.27", "1.27"},
+		"quay.io/org/app@sha256:abc":           {"quay.io", "org/", "", "latest"},
+		"registry.corp:5000/team-a/sub/app:v1": {"registry.corp:5000", "team-a/sub/", "app:v1", "v1"},
+	} {
+		reg := NewRegistry(image)
+		// Unlike the image references, the registry is not defaulted, since the allowed registries require fully qualified images.
+		assert.Equal(t, expected, [4]string{reg.Registry(), reg.Repository(), reg.Image(), reg.Tag()}, image)
+	}
+}
+
+func TestVerifyImageReference(t *testing.T) {
+	spec := api.ImageReferenceSpec{
+		ForbidLatestTag: true,
+		ForbidUntagged:  true,
+		DigestRequiredRegistries: &api.AllowedListSpec{
+			Exact: []string{"quay.io"},
+		},
+		AllowedRepositories: []string{"registry.corp/team-a/*", "registry.corp/shared/base"},
+	}
+
+	for _, ok := range []string{
+		"docker.io/library/busybox:1.36",
+		"quay.io/org/app@sha256:abc",
+		"registry.corp/team-a/app:v1",
+		"registry.corp/team-a/nested/app:v1",
+		"registry.corp/shared/base:v1",
+	} {
+		assert.NoError(t, verifyImageReference(ok, spec), ok)
+	}
+
+	for _, ko := range []string{
+		"busybox",
+		"busybox:latest",
+		"quay.io/org/app:v1",
+		"registry.corp/team-b/app:v1",
+		"registry.corp/shared/base-extra:v1",
+	} {
+		assert.Error(t, verifyImageReference(ko, spec), ko)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type imageVerification struct {
+	verifier *signatureVerifier
+}
+
+// ImageVerification returns the handler verifying the container images signatures:
+// the same handler should be shared across the routes to share the verification cache.
+func ImageVerification() capsulewebhook.Handler {
+	return newImageVerification(nil)
+}
+
+func newImageVerification(client *http.Client) *imageVerification {
+	return &imageVerification{verifier: newSignatureVerifier(newRegistryClient(client))}
+}
+
+func (h *imageVerification) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *imageVerification) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// Must be validated on update events since updates to the container images are allowed:
+// unchanged images are not hitting the registry again, thanks to the verification cache.
+// The registry credentials are resolved from the image pull Secrets of the Pod, and of its ServiceAccount.
+func (h *imageVerification) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *imageVerification) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || len(tnt.Spec.ImageVerification) == 0 {
+		return nil
+	}
+
+	keychain, err := pullSecretsKeychain(ctx, c, req.Namespace, spec)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	for _, container := range containers(spec) {
+		if err = h.verifier.Verify(ctx, container.Image, tnt.Spec.ImageVerification, keychain); err != nil {
+			recorder.Eventf(tnt, corev1.EventTypeWarning, "ImageVerificationFailed", "%s %s/%s is using the container image %s whose signature cannot be verified", req.Kind.Kind, req.Namespace, req.Name, container.Image)
+
+			response := admission.Denied(NewImageVerificationFailed(container.Image, err).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
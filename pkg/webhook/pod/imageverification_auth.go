@@ -0,0 +1,150 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// registryCredentials are the credentials used to authenticate against a registry, as found in the image pull Secrets.
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+// registryKeychain maps the registry hosts to the credentials to use for them.
+type registryKeychain map[string]registryCredentials
+
+// Resolve returns the credentials for the given registry, if any: the anonymous access is used otherwise.
+func (k registryKeychain) Resolve(registry string) *registryCredentials {
+	if credentials, ok := k[registry]; ok {
+		return &credentials
+	}
+
+	return nil
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// pullSecretsKeychain returns the registry credentials stored in the image pull Secrets of the given Pod specification,
+// along with the ones of its ServiceAccount, as the kubelet does when pulling the images.
+// The Secrets and ServiceAccounts which cannot be found are ignored, since the anonymous access could be enough.
+func pullSecretsKeychain(ctx context.Context, c client.Client, namespace string, spec *corev1.PodSpec) (registryKeychain, error) {
+	references := append([]corev1.LocalObjectReference(nil), spec.ImagePullSecrets...)
+
+	serviceAccountName := spec.ServiceAccountName
+	if len(serviceAccountName) == 0 {
+		serviceAccountName = "default"
+	}
+
+	sa := &corev1.ServiceAccount{}
+
+	switch err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: serviceAccountName}, sa); {
+	case err == nil:
+		references = append(references, sa.ImagePullSecrets...)
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	keychain := registryKeychain{}
+
+	for _, reference := range references {
+		secret := &corev1.Secret{}
+
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: reference.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for host, entry := range dockerConfigEntries(secret) {
+			credentials, ok := entry.credentials()
+			if !ok {
+				continue
+			}
+			// The first Secret providing credentials for a registry takes precedence.
+			if _, found := keychain[host]; !found {
+				keychain[host] = credentials
+			}
+		}
+	}
+
+	return keychain, nil
+}
+
+// dockerConfigEntries returns the entries of the given image pull Secret, keyed by the normalized registry host.
+func dockerConfigEntries(secret *corev1.Secret) map[string]dockerConfigEntry {
+	entries := map[string]dockerConfigEntry{}
+
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		config := struct {
+			Auths map[string]dockerConfigEntry `json:"auths"`
+		}{}
+
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil
+		}
+
+		entries = config.Auths
+	case corev1.SecretTypeDockercfg:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &entries); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	normalized := make(map[string]dockerConfigEntry, len(entries))
+
+	for key, entry := range entries {
+		normalized[registryHost(key)] = entry
+	}
+
+	return normalized
+}
+
+func (e dockerConfigEntry) credentials() (registryCredentials, bool) {
+	if len(e.Username) > 0 || len(e.Password) > 0 {
+		return registryCredentials{Username: e.Username, Password: e.Password}, true
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+	if err != nil {
+		return registryCredentials{}, false
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+
+	return registryCredentials{Username: username, Password: password}, ok
+}
+
+// registryHost normalizes the keys of the Docker configuration, such as https://index.docker.io/v1/, to the registry host.
+func registryHost(key string) string {
+	host := key
+
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+
+	host, _, _ = strings.Cut(host, "/")
+
+	return api.NormalizeRegistry(host)
+}
@@ -0,0 +1,25 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+)
+
+type imageVerificationFailedError struct {
+	image string
+	err   error
+}
+
+func NewImageVerificationFailed(image string, err error) error {
+	return &imageVerificationFailedError{image: image, err: err}
+}
+
+func (f imageVerificationFailedError) Error() string {
+	return fmt.Sprintf("Container image %s signature verification failed, as required by the current Tenant: %s", f.image, f.err.Error())
+}
+
+func (f imageVerificationFailedError) Unwrap() error {
+	return f.err
+}
@@ -0,0 +1,244 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Docker Hub is serving the registry API from a different host than the one used in the image references.
+	defaultRegistryHost = "registry-1.docker.io"
+	// Upper bound for manifests and signature payloads, both are expected to be small JSON documents.
+	maxRegistryResponseSize = 4 << 20
+)
+
+var errRegistryNotFound = errors.New("not found")
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+type registryDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type registryManifest struct {
+	MediaType string               `json:"mediaType"`
+	Layers    []registryDescriptor `json:"layers"`
+}
+
+// registryClient is a minimal OCI distribution API client,
+// providing just what is required to retrieve the cosign signatures of an image.
+// The requests are anonymous, unless credentials are provided: these are used for both the basic and the token authentication.
+type registryClient struct {
+	client *http.Client
+}
+
+func newRegistryClient(client *http.Client) *registryClient {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	return &registryClient{client: client}
+}
+
+// SignatureManifest returns the cosign signature manifest attached to the given digest,
+// stored using the sha256-<hex>.sig tag in the same repository of the image.
+func (r *registryClient) SignatureManifest(ctx context.Context, ref ImageRef, digest string, credentials *registryCredentials) (*registryManifest, error) {
+	body, _, err := r.get(ctx, ref, credentials, "manifests/"+strings.Replace(digest, ":", "-", 1)+".sig", manifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &registryManifest{}
+	if err = json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("cannot decode signature manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Blob returns the content of the given blob, ensuring it's matching its digest.
+func (r *registryClient) Blob(ctx context.Context, ref ImageRef, digest string, credentials *registryCredentials) ([]byte, error) {
+	body, _, err := r.get(ctx, ref, credentials, "blobs/"+digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if actual := sha256Digest(body); actual != digest {
+		return nil, fmt.Errorf("blob digest mismatch, expected %s, got %s", digest, actual)
+	}
+
+	return body, nil
+}
+
+func (r *registryClient) get(ctx context.Context, ref ImageRef, credentials *registryCredentials, path string, accept ...string) ([]byte, http.Header, error) {
+	host := ref.Registry
+	if host == defaultRegistry {
+		host = defaultRegistryHost
+	}
+
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", host, ref.Repository, path)
+
+	res, err := r.do(ctx, endpoint, "", accept)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Public registries are requiring an anonymous token even for pulling public images.
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge := res.Header.Get("WWW-Authenticate")
+
+		_ = res.Body.Close()
+
+		authorization, authErr := r.authorization(ctx, challenge, credentials)
+		if authErr != nil {
+			return nil, nil, authErr
+		}
+
+		if res, err = r.do(ctx, endpoint, authorization, accept); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, nil, errRegistryNotFound
+	case res.StatusCode != http.StatusOK:
+		return nil, nil, fmt.Errorf("unexpected status code %d from %s", res.StatusCode, endpoint)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxRegistryResponseSize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, res.Header, nil
+}
+
+func (r *registryClient) do(ctx context.Context, endpoint, authorization string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ","))
+	}
+
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return r.client.Do(req)
+}
+
+// authorization returns the Authorization header satisfying the given WWW-Authenticate challenge:
+// the basic authentication requires credentials, while bearer tokens are retrieved anonymously if these are missing.
+func (r *registryClient) authorization(ctx context.Context, challenge string, credentials *registryCredentials) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+
+	switch {
+	case strings.EqualFold(scheme, "Basic") && credentials != nil:
+		return "Basic " + credentials.basicAuth(), nil
+	case strings.EqualFold(scheme, "Basic"):
+		return "", errors.New("the registry requires authentication, but no image pull Secret provides credentials for it")
+	case !strings.EqualFold(scheme, "Bearer"):
+		return "", fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+
+	token, err := r.token(ctx, params, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	return "Bearer " + token, nil
+}
+
+// token retrieves a bearer token according to the parameters of the WWW-Authenticate challenge.
+func (r *registryClient) token(ctx context.Context, params string, credentials *registryCredentials) (string, error) {
+	query, realm := url.Values{}, ""
+
+	for _, param := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+
+		value = strings.Trim(value, `"`)
+
+		if key == "realm" {
+			realm = value
+
+			continue
+		}
+
+		query.Set(key, value)
+	}
+
+	if len(realm) == 0 {
+		return "", fmt.Errorf("missing realm in registry authentication challenge parameters %q", params)
+	}
+
+	var authorization string
+	if credentials != nil {
+		authorization = "Basic " + credentials.basicAuth()
+	}
+
+	res, err := r.do(ctx, realm+"?"+query.Encode(), authorization, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d retrieving registry token", res.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+
+	if err = json.NewDecoder(io.LimitReader(res.Body, maxRegistryResponseSize)).Decode(&token); err != nil {
+		return "", fmt.Errorf("cannot decode registry token: %w", err)
+	}
+
+	if len(token.Token) > 0 {
+		return token.Token, nil
+	}
+
+	return token.AccessToken, nil
+}
+
+func (c registryCredentials) basicAuth() string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
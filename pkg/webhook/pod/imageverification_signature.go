@@ -0,0 +1,194 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// Bounds the amount of cached verifications, the cache is flushed once reached.
+	maxCachedVerifications = 4096
+)
+
+var errImageNotPinned = errors.New("image must be referenced by digest, since tags can be moved to unsigned images after the admission")
+
+// simpleSigning is the payload signed by cosign, binding the signature to the image manifest digest.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// signatureVerifier verifies cosign signatures using the trusted public keys only,
+// without requiring any transparency log.
+// Successful verifications are cached by digest, since both the image and the signature payload are immutable:
+// failures are not cached, allowing signatures pushed afterward to be taken into account.
+type signatureVerifier struct {
+	registry *registryClient
+
+	mu       sync.RWMutex
+	verified map[string]struct{}
+}
+
+func newSignatureVerifier(registry *registryClient) *signatureVerifier {
+	return &signatureVerifier{
+		registry: registry,
+		verified: map[string]struct{}{},
+	}
+}
+
+// Verify returns an error if the given image is not matching all the policies it's subject to.
+// The images subject to any policy must be referenced by digest: the tags are mutable,
+// and the image pulled by the kubelet could differ from the verified one.
+func (v *signatureVerifier) Verify(ctx context.Context, image string, policies []api.ImageVerificationSpec, keychain registryKeychain) error {
+	ref := NewImageRef(image)
+
+	var matching []api.ImageVerificationSpec
+
+	for _, policy := range policies {
+		if ref.MatchRepository(policy.Pattern) {
+			matching = append(matching, policy)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	if len(ref.Digest) == 0 {
+		return errImageNotPinned
+	}
+
+	digest, credentials := ref.Digest, keychain.Resolve(ref.Registry)
+
+	for _, policy := range matching {
+		key := cacheKey(ref, digest, policy)
+
+		if v.isCached(key) {
+			continue
+		}
+
+		keys, err := policy.ParsePublicKeys()
+		if err != nil {
+			return err
+		}
+
+		if err = v.verify(ctx, ref, digest, keys, credentials); err != nil {
+			return fmt.Errorf("no valid signature for pattern %s: %w", policy.Pattern, err)
+		}
+
+		v.cache(key)
+	}
+
+	return nil
+}
+
+func (v *signatureVerifier) verify(ctx context.Context, ref ImageRef, digest string, keys []crypto.PublicKey, credentials *registryCredentials) error {
+	manifest, err := v.registry.SignatureManifest(ctx, ref, digest, credentials)
+	if err != nil {
+		if errors.Is(err, errRegistryNotFound) {
+			return errors.New("image is not signed")
+		}
+
+		return fmt.Errorf("cannot retrieve signatures: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+
+		payload, err := v.registry.Blob(ctx, ref, layer.Digest, credentials)
+		if err != nil {
+			return fmt.Errorf("cannot retrieve signature payload: %w", err)
+		}
+
+		if !verifySignature(keys, payload, signature) {
+			continue
+		}
+
+		claims := simpleSigning{}
+		if err = json.Unmarshal(payload, &claims); err != nil {
+			continue
+		}
+
+		if claims.Critical.Image.DockerManifestDigest == digest {
+			return nil
+		}
+	}
+
+	return errors.New("none of the signatures has been issued by the trusted public keys")
+}
+
+func (v *signatureVerifier) isCached(key string) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	_, ok := v.verified[key]
+
+	return ok
+}
+
+func (v *signatureVerifier) cache(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.verified) >= maxCachedVerifications {
+		v.verified = map[string]struct{}{}
+	}
+
+	v.verified[key] = struct{}{}
+}
+
+// verifySignature returns true if the signature has been issued for the payload by any of the given keys.
+func verifySignature(keys []crypto.PublicKey, payload, signature []byte) bool {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			sum := sha256.Sum256(payload)
+
+			if ecdsa.VerifyASN1(k, sum[:], signature) {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cacheKey identifies a verification by the image digest and the set of trusted keys,
+// so that changes to the policy are invalidating the cached results.
+func cacheKey(ref ImageRef, digest string, policy api.ImageVerificationSpec) string {
+	keys := append([]string(nil), policy.PublicKeys...)
+	sort.Strings(keys)
+
+	return sha256Digest([]byte(ref.Registry + "/" + ref.Repository + "@" + digest + "\n" + strings.Join(keys, "\n")))
+}
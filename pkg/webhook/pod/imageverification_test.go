@@ -0,0 +1,225 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// testRegistry is serving the manifests and blobs of a single repository, following the cosign layout for signatures.
+type testRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	requests  atomic.Int32
+	// When set, the requests must be authenticated using the basic authentication.
+	credentials *registryCredentials
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.requests.Add(1)
+
+	if r.credentials != nil && req.Header.Get("Authorization") != "Basic "+r.credentials.basicAuth() {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/team-a/app/")
+
+	var content []byte
+
+	switch {
+	case strings.HasPrefix(path, "manifests/"):
+		content = r.manifests[strings.TrimPrefix(path, "manifests/")]
+	case strings.HasPrefix(path, "blobs/"):
+		content = r.blobs[strings.TrimPrefix(path, "blobs/")]
+	}
+
+	if content == nil {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	_, _ = w.Write(content)
+}
+
+// sign pushes the cosign signature of the given manifest digest, issued using the given signer.
+func (r *testRegistry) sign(t *testing.T, digest string, signer func([]byte) []byte) {
+	t.Helper()
+
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"registry/team-a/app"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}`)
+	payloadDigest := sha256Digest(payload)
+
+	manifest, err := json.Marshal(registryManifest{
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Layers: []registryDescriptor{{
+			MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+			Digest:      payloadDigest,
+			Size:        int64(len(payload)),
+			Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signer(payload))},
+		}},
+	})
+	require.NoError(t, err)
+
+	r.blobs[payloadDigest] = payload
+	r.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = manifest
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestImageVerification(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecdsaSigner := func(key *ecdsa.PrivateKey) func([]byte) []byte {
+		return func(payload []byte) []byte {
+			sum := sha256.Sum256(payload)
+
+			signature, signErr := ecdsa.SignASN1(rand.Reader, key, sum[:])
+			require.NoError(t, signErr)
+
+			return signature
+		}
+	}
+
+	registry := &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+
+	images := map[string][]byte{}
+
+	for _, tag := range []string{"ecdsa", "ed25519", "untrusted", "unsigned"} {
+		manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","annotations":{"tag":"` + tag + `"}}`)
+		registry.manifests[tag] = manifest
+		images[tag] = manifest
+	}
+
+	registry.sign(t, sha256Digest(images["ecdsa"]), ecdsaSigner(ecdsaKey))
+	registry.sign(t, sha256Digest(images["ed25519"]), func(payload []byte) []byte { return ed25519.Sign(edKey, payload) })
+	registry.sign(t, sha256Digest(images["untrusted"]), ecdsaSigner(untrustedKey))
+
+	server := httptest.NewTLSServer(registry)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	policies := []api.ImageVerificationSpec{{
+		Pattern:    host + "/team-a/*",
+		PublicKeys: []string{publicKeyPEM(t, &ecdsaKey.PublicKey), publicKeyPEM(t, edPublic)},
+	}}
+
+	verifier := newImageVerification(server.Client()).verifier
+	ctx := context.Background()
+
+	image := func(tag string) string {
+		return host + "/team-a/app:" + tag + "@" + sha256Digest(images[tag])
+	}
+
+	assert.NoError(t, verifier.Verify(ctx, image("ecdsa"), policies, nil))
+	assert.NoError(t, verifier.Verify(ctx, image("ed25519"), policies, nil))
+	assert.NoError(t, verifier.Verify(ctx, host+"/team-a/app@"+sha256Digest(images["ecdsa"]), policies, nil))
+	assert.Error(t, verifier.Verify(ctx, image("untrusted"), policies, nil))
+	assert.Error(t, verifier.Verify(ctx, image("unsigned"), policies, nil))
+	// Tags can be moved after the admission: the images subject to a policy must be pinned by digest.
+	assert.ErrorIs(t, verifier.Verify(ctx, host+"/team-a/app:ecdsa", policies, nil), errImageNotPinned)
+	// Images not matching any pattern are not verified at all.
+	assert.NoError(t, verifier.Verify(ctx, "docker.io/library/busybox:1.36", policies, nil))
+
+	// Verifications are cached by digest, without hitting the registry again.
+	registry.requests.Store(0)
+	assert.NoError(t, verifier.Verify(ctx, image("ecdsa"), policies, nil))
+	assert.Equal(t, int32(0), registry.requests.Load())
+
+	// Private registries are accessed using the credentials of the image pull Secrets.
+	registry.credentials = &registryCredentials{Username: "alice", Password: "s3cr3t"}
+
+	verifier = newImageVerification(server.Client()).verifier
+
+	assert.Error(t, verifier.Verify(ctx, image("ed25519"), policies, registryKeychain{}))
+	assert.Error(t, verifier.Verify(ctx, image("ed25519"), policies, registryKeychain{host: {Username: "alice", Password: "wrong"}}))
+	assert.NoError(t, verifier.Verify(ctx, image("ed25519"), policies, registryKeychain{host: *registry.credentials}))
+}
+
+func TestPullSecretsKeychain(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hub", Namespace: "oil-production"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"` +
+				base64.StdEncoding.EncodeToString([]byte("alice:hub")) + `"}}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "corp", Namespace: "oil-production"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.corp:5000":{"username":"robot","password":"corp"}}}`)},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "oil-production"},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "corp"}},
+		},
+	).Build()
+
+	keychain, err := pullSecretsKeychain(context.Background(), c, "oil-production", &corev1.PodSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "hub"}, {Name: "missing"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, registryKeychain{
+		"docker.io":          {Username: "alice", Password: "hub"},
+		"registry.corp:5000": {Username: "robot", Password: "corp"},
+	}, keychain)
+
+	keychain, err = pullSecretsKeychain(context.Background(), c, "oil-production", &corev1.PodSpec{ServiceAccountName: "missing"})
+	require.NoError(t, err)
+	assert.Empty(t, keychain)
+}
+
+func TestImageVerificationSpecParsePublicKeys(t *testing.T) {
+	malformed := "-----BEGIN PUBLIC KEY-----\nZm9v\n-----END PUBLIC KEY-----\n"
+
+	_, err := api.ImageVerificationSpec{Pattern: "registry/*", PublicKeys: []string{"not a key"}}.ParsePublicKeys()
+	assert.Error(t, err)
+
+	_, err = api.ImageVerificationSpec{Pattern: "registry/*", PublicKeys: []string{malformed}}.ParsePublicKeys()
+	assert.Error(t, err)
+}
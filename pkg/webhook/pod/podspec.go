@@ -0,0 +1,151 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// container abstracts the fields shared by containers, init containers, and ephemeral containers.
+type container struct {
+	Name            string
+	Image           string
+	ImagePullPolicy corev1.PullPolicy
+}
+
+// podSpec returns the Pod specification of the admitted object:
+// this can be a Pod, or the Pod template of a workload controller.
+func podSpec(decoder admission.Decoder, req admission.Request) (*corev1.PodSpec, error) {
+	return decodePodSpec(decoder, req.Kind.Kind, req.Object)
+}
+
+// decodePodSpec returns the Pod specification of the given raw object of the provided kind.
+func decodePodSpec(decoder admission.Decoder, kind string, raw runtime.RawExtension) (*corev1.PodSpec, error) {
+	_, spec, err := decodePodTemplate(decoder, kind, raw)
+
+	return spec, err
+}
+
+// podTemplate returns the metadata and the specification of the Pod, or of the Pod template, of the admitted object.
+func podTemplate(decoder admission.Decoder, req admission.Request) (*metav1.ObjectMeta, *corev1.PodSpec, error) {
+	return decodePodTemplate(decoder, req.Kind.Kind, req.Object)
+}
+
+// decodePodTemplate returns the Pod metadata and specification of the given raw object of the provided kind.
+func decodePodTemplate(decoder admission.Decoder, kind string, raw runtime.RawExtension) (*metav1.ObjectMeta, *corev1.PodSpec, error) {
+	switch kind {
+	case "Pod":
+		obj := &corev1.Pod{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.ObjectMeta, &obj.Spec, nil
+	case "Deployment":
+		obj := &appsv1.Deployment{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.Template.ObjectMeta, &obj.Spec.Template.Spec, nil
+	case "StatefulSet":
+		obj := &appsv1.StatefulSet{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.Template.ObjectMeta, &obj.Spec.Template.Spec, nil
+	case "DaemonSet":
+		obj := &appsv1.DaemonSet{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.Template.ObjectMeta, &obj.Spec.Template.Spec, nil
+	case "ReplicaSet":
+		obj := &appsv1.ReplicaSet{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.Template.ObjectMeta, &obj.Spec.Template.Spec, nil
+	case "Job":
+		obj := &batchv1.Job{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.Template.ObjectMeta, &obj.Spec.Template.Spec, nil
+	case "CronJob":
+		obj := &batchv1.CronJob{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, nil, err
+		}
+
+		return &obj.Spec.JobTemplate.Spec.Template.ObjectMeta, &obj.Spec.JobTemplate.Spec.Template.Spec, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported kind %s, cannot extract the Pod specification", kind)
+	}
+}
+
+// podSpecPath returns the field path of the Pod specification within the admitted object.
+func podSpecPath(req admission.Request) *field.Path {
+	switch req.Kind.Kind {
+	case "Pod":
+		return field.NewPath("spec")
+	case "CronJob":
+		return field.NewPath("spec", "jobTemplate", "spec", "template", "spec")
+	default:
+		return field.NewPath("spec", "template", "spec")
+	}
+}
+
+// isPodSpecChanged returns true when the update is changing the Pod specification of the admitted object,
+// rather than its metadata, or the other fields of the workload controller, such as the replicas:
+// for Pods, this happens for the mutable fields only, such as the images and the ephemeral containers.
+func isPodSpecChanged(decoder admission.Decoder, req admission.Request) (bool, error) {
+	if len(req.OldObject.Raw) == 0 {
+		return true, nil
+	}
+
+	actual, err := podSpec(decoder, req)
+	if err != nil {
+		return false, err
+	}
+
+	old, err := decodePodSpec(decoder, req.Kind.Kind, req.OldObject)
+	if err != nil {
+		return false, err
+	}
+
+	return !equality.Semantic.DeepEqual(actual, old), nil
+}
+
+// containers returns all the containers of the given Pod specification, including init and ephemeral ones.
+func containers(spec *corev1.PodSpec) []container {
+	out := make([]container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+
+	for _, c := range spec.InitContainers {
+		out = append(out, container{Name: c.Name, Image: c.Image, ImagePullPolicy: c.ImagePullPolicy})
+	}
+
+	for _, c := range spec.Containers {
+		out = append(out, container{Name: c.Name, Image: c.Image, ImagePullPolicy: c.ImagePullPolicy})
+	}
+
+	for _, c := range spec.EphemeralContainers {
+		out = append(out, container{Name: c.Name, Image: c.Image, ImagePullPolicy: c.ImagePullPolicy})
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestIsPodSpecChanged(t *testing.T) {
+	decoder := admission.NewDecoder(clientgoscheme.Scheme)
+
+	raw := func(obj runtime.Object) runtime.RawExtension {
+		data, err := json.Marshal(obj)
+		assert.NoError(t, err)
+
+		return runtime.RawExtension{Raw: data}
+	}
+
+	deployment := func(image string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+				},
+			},
+		}
+	}
+
+	pod := func(image string) *corev1.Pod {
+		return &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+		}
+	}
+
+	debugged := pod("nginx:1.25")
+	debugged.Spec.EphemeralContainers = []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox"}}}
+
+	labeled := pod("nginx:1.25")
+	labeled.Labels = map[string]string{"env": "production"}
+
+	for name, tc := range map[string]struct {
+		kind        string
+		subResource string
+		old, obj    runtime.Object
+		expected    bool
+	}{
+		"scaled workload":                    {kind: "Deployment", old: deployment("nginx:1.25", 1), obj: deployment("nginx:1.25", 3), expected: false},
+		"workload with changed Pod template": {kind: "Deployment", old: deployment("nginx:1.25", 1), obj: deployment("nginx:1.26", 1), expected: true},
+		"Pod metadata update":                {kind: "Pod", old: pod("nginx:1.25"), obj: labeled, expected: false},
+		"Pod image update":                   {kind: "Pod", old: pod("nginx:1.25"), obj: pod("nginx:1.26"), expected: true},
+		"Pod ephemeral containers":           {kind: "Pod", subResource: "ephemeralcontainers", old: pod("nginx:1.25"), obj: debugged, expected: true},
+	} {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:        metav1.GroupVersionKind{Kind: tc.kind},
+			SubResource: tc.subResource,
+			Operation:   admissionv1.Update,
+			Object:      raw(tc.obj),
+			OldObject:   raw(tc.old),
+		}}
+
+		actual, err := isPodSpecChanged(decoder, req)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.expected, actual, name)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"net/http"
 
 	corev1 "k8s.io/api/core/v1"
+	schedulev1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -24,74 +27,144 @@ func PriorityClass() capsulewebhook.Handler {
 
 func (h *priorityClass) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		pod := &corev1.Pod{}
-		if err := decoder.Decode(req, pod); err != nil {
-			return utils.ErroredResponse(err)
-		}
-
-		tnt, err := utils.TenantByStatusNamespace(ctx, c, pod.Namespace)
-		if err != nil {
-			return utils.ErroredResponse(err)
-		}
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
 
-		if tnt == nil {
-			return nil
-		}
+func (h *priorityClass) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
 
-		allowed := tnt.Spec.PriorityClasses
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
 
-		if allowed == nil {
-			return nil
-		}
+	if tnt == nil {
+		return nil
+	}
 
-		priorityClassName := pod.Spec.PriorityClassName
+	priorityClassName := spec.PriorityClassName
 
-		if len(priorityClassName) == 0 {
-			// We don't have to force Pod to specify a Priority Class
-			return nil
-		}
+	if len(priorityClassName) == 0 {
+		// We don't have to force Pod to specify a Priority Class, although the bounds apply to the default one
+		return h.validateDefaultBounds(ctx, c, recorder, req, tnt, spec)
+	}
 
-		selector := false
+	var priorityClassObj *schedulev1.PriorityClass
 
-		// Verify if the StorageClass exists and matches the label selector/expression
-		if len(allowed.MatchExpressions) > 0 || len(allowed.MatchLabels) > 0 {
-			priorityClassObj, err := utils.GetPriorityClassByName(ctx, c, priorityClassName)
-			if err != nil {
-				response := admission.Errored(http.StatusInternalServerError, err)
+	// The Priority Class is required to verify the label selector/expression, and the bounds
+	if allowed := tnt.Spec.PriorityClasses; tnt.Spec.PriorityClassBounds != nil || (allowed != nil && (len(allowed.MatchExpressions) > 0 || len(allowed.MatchLabels) > 0)) {
+		if priorityClassObj, err = utils.GetPriorityClassByName(ctx, c, priorityClassName); err != nil {
+			// The bounds cannot be verified for a missing Priority Class
+			if apierrors.IsNotFound(err) && tnt.Spec.PriorityClassBounds != nil {
+				response := admission.Denied(NewPodPriorityClassUnresolved(priorityClassName).Error())
 
 				return &response
 			}
 
-			// Storage Class is present, check if it matches the selector
-			if priorityClassObj != nil {
-				selector = allowed.SelectorMatch(priorityClassObj)
-			}
+			response := admission.Errored(http.StatusInternalServerError, err)
+
+			return &response
 		}
+	}
 
-		switch {
-		case allowed.MatchDefault(priorityClassName):
-			// Allow if given Priority Class is equal tenant default (eventough it's not allowed by selector)
-			return nil
-		case allowed.Match(priorityClassName) || selector:
-			return nil
-		default:
-			recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPriorityClass", "Pod %s/%s is using Priority Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, priorityClassName)
+	if allowed := tnt.Spec.PriorityClasses; allowed != nil {
+		selector := false
+
+		// Priority Class is present, check if it matches the selector
+		if priorityClassObj != nil && (len(allowed.MatchExpressions) > 0 || len(allowed.MatchLabels) > 0) {
+			selector = allowed.SelectorMatch(priorityClassObj)
+		}
+
+		// Allow if given Priority Class is equal tenant default (eventough it's not allowed by selector)
+		if !allowed.MatchDefault(priorityClassName) && !allowed.Match(priorityClassName) && !selector {
+			recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPriorityClass", "%s %s/%s is using Priority Class %s is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, priorityClassName)
 
 			response := admission.Denied(NewPodPriorityClassForbidden(priorityClassName, *allowed).Error())
 
 			return &response
 		}
 	}
+
+	if priorityClassObj != nil {
+		return h.validateBounds(recorder, req, tnt, spec, priorityClassName, priorityClassObj.Value, priorityClassObj.PreemptionPolicy)
+	}
+
+	return nil
+}
+
+// validateDefaultBounds verifies the bounds against the priority assigned to the Pods with no Priority Class:
+// the one of the global default Priority Class, if any, or zero, as the Priority admission plugin does.
+func (h *priorityClass) validateDefaultBounds(ctx context.Context, c client.Client, recorder record.EventRecorder, req admission.Request, tnt *capsulev1beta2.Tenant, spec *corev1.PodSpec) *admission.Response {
+	if tnt.Spec.PriorityClassBounds == nil {
+		return nil
+	}
+
+	classes := &schedulev1.PriorityClassList{}
+	if err := c.List(ctx, classes); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	var globalDefault *schedulev1.PriorityClass
+
+	for i := range classes.Items {
+		// With several global defaults, the one with the lowest value is used
+		if classes.Items[i].GlobalDefault && (globalDefault == nil || classes.Items[i].Value < globalDefault.Value) {
+			globalDefault = &classes.Items[i]
+		}
+	}
+
+	if globalDefault == nil {
+		return h.validateBounds(recorder, req, tnt, spec, "default", 0, nil)
+	}
+
+	return h.validateBounds(recorder, req, tnt, spec, globalDefault.GetName(), globalDefault.Value, globalDefault.PreemptionPolicy)
 }
 
-func (h *priorityClass) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
-	return func(context.Context, admission.Request) *admission.Response {
+func (h *priorityClass) validateBounds(recorder record.EventRecorder, req admission.Request, tnt *capsulev1beta2.Tenant, spec *corev1.PodSpec, priorityClassName string, value int32, classPolicy *corev1.PreemptionPolicy) *admission.Response {
+	bounds := tnt.Spec.PriorityClassBounds
+	if bounds == nil {
 		return nil
 	}
+	// Pods are already assigned the preemption policy of the class by the Priority admission plugin, unlike the Pod templates
+	policy := corev1.PreemptLowerPriority
+
+	switch {
+	case spec.PreemptionPolicy != nil:
+		policy = *spec.PreemptionPolicy
+	case classPolicy != nil:
+		policy = *classPolicy
+	}
+
+	if !bounds.InRange(value) || !bounds.AllowsPreemptionPolicy(policy) {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPriorityClass", "%s %s/%s is using Priority Class %s exceeding the bounds of the current Tenant", req.Kind.Kind, req.Namespace, req.Name, priorityClassName)
+
+		response := admission.Denied(NewPodPriorityClassOutOfBounds(priorityClassName, value, policy, *bounds).Error())
+
+		return &response
+	}
+
+	return nil
 }
 
-func (h *priorityClass) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+func (h *priorityClass) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
 	return func(context.Context, admission.Request) *admission.Response {
 		return nil
 	}
 }
+
+func (h *priorityClass) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
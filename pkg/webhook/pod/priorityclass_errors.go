@@ -5,6 +5,9 @@ package pod
 
 import (
 	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -27,3 +30,55 @@ func (f podPriorityClassForbiddenError) Error() (err string) {
 
 	return utils.DefaultAllowedValuesErrorMessage(f.spec, msg)
 }
+
+type podPriorityClassOutOfBoundsError struct {
+	priorityClassName string
+	value             int32
+	policy            corev1.PreemptionPolicy
+	bounds            api.PriorityClassBoundsSpec
+}
+
+func NewPodPriorityClassOutOfBounds(priorityClassName string, value int32, policy corev1.PreemptionPolicy, bounds api.PriorityClassBoundsSpec) error {
+	return &podPriorityClassOutOfBoundsError{
+		priorityClassName: priorityClassName,
+		value:             value,
+		policy:            policy,
+		bounds:            bounds,
+	}
+}
+
+func (f podPriorityClassOutOfBoundsError) Error() string {
+	var constraints []string
+
+	if f.bounds.Min != nil {
+		constraints = append(constraints, fmt.Sprintf("min value %d", *f.bounds.Min))
+	}
+
+	if f.bounds.Max != nil {
+		constraints = append(constraints, fmt.Sprintf("max value %d", *f.bounds.Max))
+	}
+
+	if len(f.bounds.AllowedPreemptionPolicies) > 0 {
+		policies := make([]string, 0, len(f.bounds.AllowedPreemptionPolicies))
+
+		for _, policy := range f.bounds.AllowedPreemptionPolicies {
+			policies = append(policies, string(policy))
+		}
+
+		constraints = append(constraints, fmt.Sprintf("preemption policies %s", strings.Join(policies, ", ")))
+	}
+
+	return fmt.Sprintf("Pod Priority Class %s, with value %d and preemption policy %s, is exceeding the bounds of the current Tenant: %s", f.priorityClassName, f.value, f.policy, strings.Join(constraints, "; "))
+}
+
+type podPriorityClassUnresolvedError struct {
+	priorityClassName string
+}
+
+func NewPodPriorityClassUnresolved(priorityClassName string) error {
+	return &podPriorityClassUnresolvedError{priorityClassName: priorityClassName}
+}
+
+func (f podPriorityClassUnresolvedError) Error() string {
+	return fmt.Sprintf("Pod Priority Class %s cannot be found, thus it cannot be verified against the bounds of the current Tenant", f.priorityClassName)
+}
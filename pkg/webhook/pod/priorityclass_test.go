@@ -0,0 +1,90 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulev1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+)
+
+func TestPriorityClassBounds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	indexer := tenantindexer.NamespacesReference{Obj: &capsulev1beta2.Tenant{}}
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec:       capsulev1beta2.TenantSpec{PriorityClassBounds: &api.PriorityClassBoundsSpec{Min: ptr.To[int32](0), Max: ptr.To[int32](1000)}},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	low := &schedulev1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 100}
+	critical := &schedulev1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "critical"}, Value: 1000000, GlobalDefault: true}
+
+	decoder := admission.NewDecoder(scheme)
+
+	request := func(priorityClassName string) admission.Request {
+		data, err := json.Marshal(&corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+			Spec:       corev1.PodSpec{PriorityClassName: priorityClassName},
+		})
+		assert.NoError(t, err)
+
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: "oil-production",
+			Name:      "web",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: data},
+		}}
+	}
+
+	for name, tc := range map[string]struct {
+		objects           []client.Object
+		priorityClassName string
+		allowed           bool
+	}{
+		"class within the bounds":             {objects: []client.Object{low}, priorityClassName: "low", allowed: true},
+		"class exceeding the bounds":          {objects: []client.Object{critical}, priorityClassName: "critical", allowed: false},
+		"missing class":                       {priorityClassName: "low", allowed: false},
+		"global default exceeding the bounds": {objects: []client.Object{low, critical}, allowed: false},
+		"no global default":                   {objects: []client.Object{low}, allowed: true},
+	} {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(indexer.Object(), indexer.Field(), indexer.Func()).WithObjects(append(tc.objects, tnt)...).Build()
+
+		response := PriorityClass().OnCreate(c, decoder, record.NewFakeRecorder(10))(context.Background(), request(tc.priorityClassName))
+
+		if tc.allowed {
+			assert.Nil(t, response, name)
+
+			continue
+		}
+
+		if assert.NotNil(t, response, name) {
+			assert.False(t, response.Allowed, name)
+			assert.Equal(t, int32(403), response.Result.Code, name)
+		}
+	}
+}
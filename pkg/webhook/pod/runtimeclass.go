@@ -49,19 +49,26 @@ func (h *runtimeClass) OnDelete(client.Client, admission.Decoder, record.EventRe
 	}
 }
 
-func (h *runtimeClass) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
-	return func(context.Context, admission.Request) *admission.Response {
-		return nil
+func (h *runtimeClass) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
 	}
 }
 
 func (h *runtimeClass) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
-	pod := &corev1.Pod{}
-	if err := decoder.Decode(req, pod); err != nil {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
 		return utils.ErroredResponse(err)
 	}
 
-	tnt, err := utils.TenantByStatusNamespace(ctx, c, pod.Namespace)
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
 	if err != nil {
 		return utils.ErroredResponse(err)
 	}
@@ -73,8 +80,8 @@ func (h *runtimeClass) validate(ctx context.Context, c client.Client, decoder ad
 	allowed := tnt.Spec.RuntimeClasses
 
 	runtimeClassName := ""
-	if pod.Spec.RuntimeClassName != nil {
-		runtimeClassName = *pod.Spec.RuntimeClassName
+	if spec.RuntimeClassName != nil {
+		runtimeClassName = *spec.RuntimeClassName
 	}
 
 	class, err := h.class(ctx, c, runtimeClassName)
@@ -92,7 +99,7 @@ func (h *runtimeClass) validate(ctx context.Context, c client.Client, decoder ad
 		// Delegating mutating webhook to specify a default RuntimeClass
 		return nil
 	case !allowed.MatchSelectByName(class):
-		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenRuntimeClass", "Pod %s/%s is using Runtime Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, runtimeClassName)
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenRuntimeClass", "%s %s/%s is using Runtime Class %s is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, runtimeClassName)
 
 		response := admission.Denied(NewPodRuntimeClassForbidden(runtimeClassName, *allowed).Error())
 
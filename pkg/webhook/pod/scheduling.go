@@ -0,0 +1,331 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type scheduling struct{}
+
+func Scheduling() capsulewebhook.Handler {
+	return &scheduling{}
+}
+
+func (h *scheduling) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *scheduling) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// The scheduling fields of Pods are immutable, besides the nodeName set upon binding, and the tolerations that can be added:
+// the Pod templates of workload controllers must be validated on update, as the tolerations of Pods.
+func (h *scheduling) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		if req.Kind.Kind == "Pod" {
+			return h.validateTolerationsUpdate(ctx, c, decoder, recorder, req)
+		}
+
+		switch changed, err := isPodSpecChanged(decoder, req); {
+		case err != nil:
+			return utils.ErroredResponse(err)
+		case !changed:
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *scheduling) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil {
+		return nil
+	}
+
+	tenants := &capsulev1beta2.TenantList{}
+	if err = c.List(ctx, tenants); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err = validateScheduling(tnt, tenants.Items, req.Kind.Kind, spec); err != nil {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenScheduling", "%s %s/%s is violating the Tenant scheduling constraints", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateTolerationsUpdate validates the tolerations added to a Pod.
+func (h *scheduling) validateTolerationsUpdate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spec, err := podSpec(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	old, err := decodePodSpec(decoder, req.Kind.Kind, req.OldObject)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if equality.Semantic.DeepEqual(spec.Tolerations, old.Tolerations) {
+		return nil
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil {
+		return nil
+	}
+
+	tenants := &capsulev1beta2.TenantList{}
+	if err = c.List(ctx, tenants); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err = validateDedicatedTaints(tnt, tenants.Items, spec); err != nil {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenScheduling", "%s %s/%s is violating the Tenant scheduling constraints", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// validateScheduling validates the scheduling constraints of the Tenant, along with the tolerations of the taints
+// of the nodes dedicated to the other Tenants. The required node affinity is checked for the Pods only,
+// since it's injected by the mutating webhook upon their creation, rather than into the Pod templates of the workloads.
+func validateScheduling(tnt *capsulev1beta2.Tenant, tenants []capsulev1beta2.Tenant, kind string, spec *corev1.PodSpec) error {
+	options := tnt.Spec.SchedulingOptions
+
+	if options.IsEnforcingPlacement() && len(spec.NodeName) > 0 {
+		return NewNodeNameForbidden(spec.NodeName)
+	}
+
+	if err := validateNodeSelector(tnt, spec); err != nil {
+		return err
+	}
+
+	if kind == "Pod" {
+		if err := validateRequiredNodeAffinity(options, spec); err != nil {
+			return err
+		}
+	}
+
+	return validateDedicatedTaints(tnt, tenants, spec)
+}
+
+func validateNodeSelector(tnt *capsulev1beta2.Tenant, spec *corev1.PodSpec) error {
+	options := tnt.Spec.SchedulingOptions
+
+	if options == nil || !options.EnforceNodeSelector || len(tnt.Spec.NodeSelector) == 0 {
+		return nil
+	}
+
+	for key, expected := range tnt.Spec.NodeSelector {
+		if value, ok := spec.NodeSelector[key]; ok && value != expected {
+			return NewNodeSelectorConflict(key, value, expected)
+		}
+	}
+
+	terms := requiredNodeSelectorTerms(spec)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	for _, term := range terms {
+		if isTermSatisfiable(term, tnt.Spec.NodeSelector) {
+			return nil
+		}
+	}
+
+	return NewNodeAffinityConflict()
+}
+
+// validateRequiredNodeAffinity ensures each of the node selector terms of the Pod is including all the requirements
+// of one of the terms required by the Tenant, as injected by the mutating webhook.
+func validateRequiredNodeAffinity(options *api.SchedulingOptions, spec *corev1.PodSpec) error {
+	if options == nil || options.RequiredNodeAffinity == nil || len(options.RequiredNodeAffinity.NodeSelectorTerms) == 0 {
+		return nil
+	}
+
+	terms := requiredNodeSelectorTerms(spec)
+	if len(terms) == 0 {
+		return NewNodeAffinityRequired()
+	}
+
+	for _, term := range terms {
+		if !isTermRestricted(term, options.RequiredNodeAffinity.NodeSelectorTerms) {
+			return NewNodeAffinityRequired()
+		}
+	}
+
+	return nil
+}
+
+// validateDedicatedTaints ensures the Pod is not tolerating the taints of the nodes dedicated to the other Tenants,
+// unless the same taints are applied to the nodes dedicated to the current one,
+// or the toleration is one of the ones injected for the current Tenant by the cluster administrators.
+func validateDedicatedTaints(tnt *capsulev1beta2.Tenant, tenants []capsulev1beta2.Tenant, spec *corev1.PodSpec) error {
+	if len(spec.Tolerations) == 0 {
+		return nil
+	}
+
+	var (
+		own      *api.DedicatedNodesSpec
+		injected []corev1.Toleration
+	)
+
+	if tnt.Spec.SchedulingOptions != nil {
+		own, injected = tnt.Spec.SchedulingOptions.DedicatedNodes, tnt.Spec.SchedulingOptions.Tolerations
+	}
+
+	for _, other := range tenants {
+		if other.GetName() == tnt.GetName() || other.Spec.SchedulingOptions == nil || other.Spec.SchedulingOptions.DedicatedNodes == nil {
+			continue
+		}
+
+		for _, taint := range other.Spec.SchedulingOptions.DedicatedNodes.Taints {
+			if own.HasTaint(taint) {
+				continue
+			}
+
+			for i := range spec.Tolerations {
+				if !spec.Tolerations[i].ToleratesTaint(&taint) || isInjectedToleration(injected, spec.Tolerations[i]) {
+					continue
+				}
+
+				return NewDedicatedTaintToleration(taint)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isInjectedToleration(injected []corev1.Toleration, toleration corev1.Toleration) bool {
+	for i := range injected {
+		if injected[i].MatchToleration(&toleration) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func requiredNodeSelectorTerms(spec *corev1.PodSpec) []corev1.NodeSelectorTerm {
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+
+	return spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+}
+
+// isTermRestricted returns true if the term is including all the requirements of at least one of the required terms.
+func isTermRestricted(term corev1.NodeSelectorTerm, required []corev1.NodeSelectorTerm) bool {
+	contains := func(requirements []corev1.NodeSelectorRequirement, requirement corev1.NodeSelectorRequirement) bool {
+		for _, r := range requirements {
+			if equality.Semantic.DeepEqual(r, requirement) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, requiredTerm := range required {
+		restricted := true
+
+		for _, requirement := range requiredTerm.MatchExpressions {
+			restricted = restricted && contains(term.MatchExpressions, requirement)
+		}
+
+		for _, requirement := range requiredTerm.MatchFields {
+			restricted = restricted && contains(term.MatchFields, requirement)
+		}
+
+		if restricted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTermSatisfiable returns false if any requirement of the term on the node selector keys is not matching the enforced values.
+func isTermSatisfiable(term corev1.NodeSelectorTerm, nodeSelector map[string]string) bool {
+	nodeLabels := labels.Set(nodeSelector)
+
+	for _, expression := range term.MatchExpressions {
+		if _, ok := nodeSelector[expression.Key]; !ok {
+			continue
+		}
+
+		var operator selection.Operator
+
+		switch expression.Operator {
+		case corev1.NodeSelectorOpIn:
+			operator = selection.In
+		case corev1.NodeSelectorOpNotIn:
+			operator = selection.NotIn
+		case corev1.NodeSelectorOpExists:
+			operator = selection.Exists
+		case corev1.NodeSelectorOpDoesNotExist:
+			operator = selection.DoesNotExist
+		case corev1.NodeSelectorOpGt:
+			operator = selection.GreaterThan
+		case corev1.NodeSelectorOpLt:
+			operator = selection.LessThan
+		default:
+			continue
+		}
+
+		requirement, err := labels.NewRequirement(expression.Key, operator, expression.Values)
+		if err != nil {
+			continue
+		}
+
+		if !requirement.Matches(nodeLabels) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type nodeNameForbiddenError struct {
+	nodeName string
+}
+
+func NewNodeNameForbidden(nodeName string) error {
+	return &nodeNameForbiddenError{nodeName: nodeName}
+}
+
+func (f nodeNameForbiddenError) Error() string {
+	return fmt.Sprintf("Binding to the node %s through the nodeName field is forbidden for the current Tenant, Pods must be placed by the scheduler", f.nodeName)
+}
+
+type nodeSelectorConflictError struct {
+	key, value, expected string
+}
+
+func NewNodeSelectorConflict(key, value, expected string) error {
+	return &nodeSelectorConflictError{key: key, value: value, expected: expected}
+}
+
+func (f nodeSelectorConflictError) Error() string {
+	return fmt.Sprintf("Node selector %s=%s is conflicting with the one enforced by the current Tenant (%s=%s)", f.key, f.value, f.key, f.expected)
+}
+
+type nodeAffinityConflictError struct{}
+
+func NewNodeAffinityConflict() error {
+	return &nodeAffinityConflictError{}
+}
+
+func (nodeAffinityConflictError) Error() string {
+	return "Required node affinity is conflicting with the node selector enforced by the current Tenant, none of the node selector terms can be satisfied"
+}
+
+type nodeAffinityRequiredError struct{}
+
+func NewNodeAffinityRequired() error {
+	return &nodeAffinityRequiredError{}
+}
+
+func (nodeAffinityRequiredError) Error() string {
+	return "Required node affinity must include the node selector terms enforced by the current Tenant"
+}
+
+type dedicatedTaintTolerationError struct {
+	taint corev1.Taint
+}
+
+func NewDedicatedTaintToleration(taint corev1.Taint) error {
+	return &dedicatedTaintTolerationError{taint: taint}
+}
+
+func (f dedicatedTaintTolerationError) Error() string {
+	return fmt.Sprintf("Tolerating the taint %s is forbidden for the current Tenant, since applied to the nodes dedicated to another Tenant", f.taint.ToString())
+}
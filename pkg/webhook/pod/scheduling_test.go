@@ -0,0 +1,110 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestValidateScheduling(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{
+		NodeSelector:      map[string]string{"pool": "oil"},
+		SchedulingOptions: &api.SchedulingOptions{EnforceNodeSelector: true},
+	}}
+
+	withAffinity := func(terms ...corev1.NodeSelectorTerm) *corev1.PodSpec {
+		return &corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}}
+	}
+
+	term := func(key string, operator corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: operator, Values: values}}}
+	}
+
+	assert.NoError(t, validateScheduling(tnt, nil, "Pod", &corev1.PodSpec{NodeSelector: map[string]string{"pool": "oil", "zone": "a"}}))
+	assert.NoError(t, validateScheduling(tnt, nil, "Pod", withAffinity(term("zone", corev1.NodeSelectorOpIn, "a"))))
+	assert.NoError(t, validateScheduling(tnt, nil, "Pod", withAffinity(term("pool", corev1.NodeSelectorOpIn, "gas"), term("pool", corev1.NodeSelectorOpExists))))
+
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", &corev1.PodSpec{NodeName: "worker-1"}))
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", &corev1.PodSpec{NodeSelector: map[string]string{"pool": "gas"}}))
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", withAffinity(term("pool", corev1.NodeSelectorOpIn, "gas"))))
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", withAffinity(term("pool", corev1.NodeSelectorOpDoesNotExist))))
+
+	// Without enforcement, the scheduling fields are not validated.
+	assert.NoError(t, validateScheduling(&capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{NodeSelector: map[string]string{"pool": "oil"}}}, nil, "Pod", &corev1.PodSpec{NodeName: "worker-1"}))
+}
+
+func TestValidateRequiredNodeAffinity(t *testing.T) {
+	zone := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}}
+	pool := corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"oil"}}
+
+	tnt := &capsulev1beta2.Tenant{Spec: capsulev1beta2.TenantSpec{
+		SchedulingOptions: &api.SchedulingOptions{RequiredNodeAffinity: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zone}},
+		}}},
+	}}
+
+	withTerms := func(terms ...corev1.NodeSelectorTerm) *corev1.PodSpec {
+		return &corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}}
+	}
+
+	assert.NoError(t, validateScheduling(tnt, nil, "Pod", withTerms(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zone}})))
+	assert.NoError(t, validateScheduling(tnt, nil, "Pod", withTerms(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{pool, zone}})))
+
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", &corev1.PodSpec{}))
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", withTerms(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{pool}})))
+	// Each term must be restricted, since terms are ORed.
+	assert.Error(t, validateScheduling(tnt, nil, "Pod", withTerms(
+		corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{pool, zone}},
+		corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{pool}},
+	)))
+	// The Pod templates of the workloads are not required to declare the terms, injected into their Pods upon creation.
+	assert.NoError(t, validateScheduling(tnt, nil, "Deployment", &corev1.PodSpec{}))
+	assert.NoError(t, validateScheduling(tnt, nil, "Deployment", withTerms(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{pool}})))
+}
+
+func TestValidateDedicatedTaints(t *testing.T) {
+	dedicated := func(name string, taints ...corev1.Taint) capsulev1beta2.Tenant {
+		return capsulev1beta2.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: capsulev1beta2.TenantSpec{SchedulingOptions: &api.SchedulingOptions{
+				DedicatedNodes: &api.DedicatedNodesSpec{Taints: taints},
+			}},
+		}
+	}
+
+	oilTaint := corev1.Taint{Key: "dedicated", Value: "oil", Effect: corev1.TaintEffectNoSchedule}
+	gasTaint := corev1.Taint{Key: "dedicated", Value: "gas", Effect: corev1.TaintEffectNoSchedule}
+
+	oil, gas := dedicated("oil", oilTaint), dedicated("gas", gasTaint)
+	tenants := []capsulev1beta2.Tenant{oil, gas}
+
+	withTolerations := func(tolerations ...corev1.Toleration) *corev1.PodSpec {
+		return &corev1.PodSpec{Tolerations: tolerations}
+	}
+
+	assert.NoError(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "oil", Effect: corev1.TaintEffectNoSchedule})))
+	assert.NoError(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Key: "gpu", Operator: corev1.TolerationOpExists})))
+
+	assert.Error(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gas", Effect: corev1.TaintEffectNoSchedule})))
+	assert.Error(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists})))
+	assert.Error(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Operator: corev1.TolerationOpExists})))
+
+	// The tolerations injected by the cluster administrators are allowed.
+	oil.Spec.SchedulingOptions.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+	assert.NoError(t, validateScheduling(&oil, tenants, "Pod", withTolerations(corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists})))
+
+	// Tenants with no dedicated nodes cannot tolerate the ones of the others.
+	assert.Error(t, validateScheduling(&capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}, tenants, "Pod", withTolerations(corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists})))
+}
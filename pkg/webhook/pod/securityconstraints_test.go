@@ -0,0 +1,93 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestValidatePodSecurity(t *testing.T) {
+	constraints := api.PodSecuritySpec{
+		AllowedCapabilities:    []corev1.Capability{"NET_BIND_SERVICE"},
+		AllowedVolumeTypes:     []string{"configMap", "hostPath"},
+		AllowedHostPaths:       []api.AllowedHostPath{{PathPrefix: "/var/log", ReadOnly: true}},
+		RunAsUser:              []api.IDRange{{Min: 1000, Max: 1999}},
+		RequireSeccompProfile:  true,
+		RequireAppArmorProfile: true,
+	}
+
+	compliant := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:       ptr.To[int64](1000),
+				SeccompProfile:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{{
+				Name:            "app",
+				SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}}},
+				VolumeMounts:    []corev1.VolumeMount{{Name: "logs", ReadOnly: true}},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log/pods"}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+			},
+		}
+	}
+
+	path := field.NewPath("spec")
+
+	assert.Empty(t, validatePodSecurity(compliant(), path, constraints))
+
+	for expected, mutate := range map[string]func(*corev1.PodSpec){
+		"spec.hostNetwork": func(spec *corev1.PodSpec) { spec.HostNetwork = true },
+		"spec.hostPID":     func(spec *corev1.PodSpec) { spec.HostPID = true },
+		"spec.hostIPC":     func(spec *corev1.PodSpec) { spec.HostIPC = true },
+		"spec.containers[0].securityContext.privileged": func(spec *corev1.PodSpec) {
+			spec.Containers[0].SecurityContext.Privileged = ptr.To(true)
+		},
+		"spec.containers[0].securityContext.capabilities.add[1]": func(spec *corev1.PodSpec) {
+			spec.Containers[0].SecurityContext.Capabilities.Add = append(spec.Containers[0].SecurityContext.Capabilities.Add, "SYS_ADMIN")
+		},
+		"spec.containers[0].securityContext.runAsUser": func(spec *corev1.PodSpec) {
+			spec.Containers[0].SecurityContext.RunAsUser = ptr.To[int64](0)
+		},
+		"spec.containers[0].securityContext.seccompProfile": func(spec *corev1.PodSpec) {
+			spec.Containers[0].SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}
+		},
+		"spec.containers[0].securityContext.appArmorProfile": func(spec *corev1.PodSpec) {
+			spec.SecurityContext.AppArmorProfile = nil
+		},
+		"spec.volumes[1]": func(spec *corev1.PodSpec) {
+			spec.Volumes[1].VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		},
+		"spec.volumes[0].hostPath.path": func(spec *corev1.PodSpec) {
+			spec.Volumes[0].HostPath.Path = "/var/logs"
+		},
+	} {
+		spec := compliant()
+		mutate(spec)
+
+		errs := validatePodSecurity(spec, path, constraints)
+		if assert.Len(t, errs, 1, expected) {
+			assert.Equal(t, expected, errs[0].Field)
+		}
+	}
+
+	// Host paths requiring read-only mounts are rejected when any container mounts them in read-write mode.
+	spec := compliant()
+	spec.InitContainers = []corev1.Container{{Name: "init", VolumeMounts: []corev1.VolumeMount{{Name: "logs"}}}}
+
+	errs := validatePodSecurity(spec, path, constraints)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "spec.volumes[0].hostPath.path", errs[0].Field)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// securedContainer is a container along with its field path, used to report the violations.
+type securedContainer struct {
+	Path            *field.Path
+	SecurityContext *corev1.SecurityContext
+	VolumeMounts    []corev1.VolumeMount
+}
+
+// validatePodSecurity returns a violation for each field of the Pod specification not satisfying the given constraints.
+func validatePodSecurity(spec *corev1.PodSpec, specPath *field.Path, constraints api.PodSecuritySpec) (errs field.ErrorList) {
+	if spec.HostNetwork && !constraints.AllowHostNetwork {
+		errs = append(errs, field.Forbidden(specPath.Child("hostNetwork"), "the host network namespace is forbidden for the current Tenant"))
+	}
+
+	if spec.HostPID && !constraints.AllowHostPID {
+		errs = append(errs, field.Forbidden(specPath.Child("hostPID"), "the host PID namespace is forbidden for the current Tenant"))
+	}
+
+	if spec.HostIPC && !constraints.AllowHostIPC {
+		errs = append(errs, field.Forbidden(specPath.Child("hostIPC"), "the host IPC namespace is forbidden for the current Tenant"))
+	}
+
+	readOnlyMounts := map[string]bool{}
+
+	for _, c := range securedContainers(spec, specPath) {
+		errs = append(errs, validateContainerSecurity(c, spec.SecurityContext, constraints)...)
+
+		for _, mount := range c.VolumeMounts {
+			if readOnly, ok := readOnlyMounts[mount.Name]; !ok || readOnly {
+				readOnlyMounts[mount.Name] = mount.ReadOnly
+			}
+		}
+	}
+
+	allowedVolumes := sets.New(constraints.AllowedVolumeTypes...)
+
+	for i, volume := range spec.Volumes {
+		volumePath := specPath.Child("volumes").Index(i)
+
+		if volumeType := volumeSourceType(volume.VolumeSource); allowedVolumes.Len() > 0 && !allowedVolumes.Has(volumeType) {
+			errs = append(errs, field.NotSupported(volumePath, volumeType, constraints.AllowedVolumeTypes))
+
+			continue
+		}
+
+		if volume.HostPath != nil && len(constraints.AllowedHostPaths) > 0 {
+			if err := validateHostPath(volume.HostPath.Path, readOnlyMounts[volume.Name], constraints.AllowedHostPaths); err != "" {
+				errs = append(errs, field.Forbidden(volumePath.Child("hostPath", "path"), err))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateContainerSecurity(c securedContainer, podContext *corev1.PodSecurityContext, constraints api.PodSecuritySpec) (errs field.ErrorList) {
+	sc, scPath := c.SecurityContext, c.Path.Child("securityContext")
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+
+	if sc.Privileged != nil && *sc.Privileged && !constraints.AllowPrivileged {
+		errs = append(errs, field.Forbidden(scPath.Child("privileged"), "privileged containers are forbidden for the current Tenant"))
+	}
+
+	if sc.Capabilities != nil {
+		allowed := sets.New(constraints.AllowedCapabilities...)
+
+		for i, capability := range sc.Capabilities.Add {
+			if !allowed.Has(capability) {
+				errs = append(errs, field.Forbidden(scPath.Child("capabilities", "add").Index(i), fmt.Sprintf("capability %s is forbidden for the current Tenant", capability)))
+			}
+		}
+	}
+
+	if len(constraints.RunAsUser) > 0 {
+		runAsUser := podContext.RunAsUser
+		if sc.RunAsUser != nil {
+			runAsUser = sc.RunAsUser
+		}
+
+		switch {
+		case runAsUser == nil:
+			errs = append(errs, field.Required(scPath.Child("runAsUser"), "the current Tenant requires containers to run as a user within the allowed ranges"))
+		case !idInRanges(*runAsUser, constraints.RunAsUser):
+			errs = append(errs, field.Invalid(scPath.Child("runAsUser"), *runAsUser, "user ID is not within the ranges allowed for the current Tenant"))
+		}
+	}
+
+	if constraints.RequireSeccompProfile {
+		profile := podContext.SeccompProfile
+		if sc.SeccompProfile != nil {
+			profile = sc.SeccompProfile
+		}
+
+		if profile == nil || (profile.Type != corev1.SeccompProfileTypeRuntimeDefault && profile.Type != corev1.SeccompProfileTypeLocalhost) {
+			errs = append(errs, field.Required(scPath.Child("seccompProfile"), "the current Tenant requires a seccomp profile of type RuntimeDefault or Localhost"))
+		}
+	}
+
+	if constraints.RequireAppArmorProfile {
+		profile := podContext.AppArmorProfile
+		if sc.AppArmorProfile != nil {
+			profile = sc.AppArmorProfile
+		}
+
+		if profile == nil || (profile.Type != corev1.AppArmorProfileTypeRuntimeDefault && profile.Type != corev1.AppArmorProfileTypeLocalhost) {
+			errs = append(errs, field.Required(scPath.Child("appArmorProfile"), "the current Tenant requires an AppArmor profile of type RuntimeDefault or Localhost"))
+		}
+	}
+
+	return errs
+}
+
+// validateHostPath returns the reason the given host path is not allowed, if any.
+func validateHostPath(hostPath string, readOnly bool, allowed []api.AllowedHostPath) string {
+	cleaned := path.Clean(hostPath)
+
+	for _, prefix := range allowed {
+		allowedPrefix := path.Clean(prefix.PathPrefix)
+
+		if cleaned != allowedPrefix && !strings.HasPrefix(cleaned, strings.TrimSuffix(allowedPrefix, "/")+"/") {
+			continue
+		}
+
+		if prefix.ReadOnly && !readOnly {
+			return fmt.Sprintf("host path %s must be mounted read-only for the current Tenant", hostPath)
+		}
+
+		return ""
+	}
+
+	return fmt.Sprintf("host path %s is not matching any of the prefixes allowed for the current Tenant", hostPath)
+}
+
+func idInRanges(id int64, ranges []api.IDRange) bool {
+	for _, r := range ranges {
+		if r.Contains(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// volumeSourceType returns the name of the volume source, as used in the Pod specification, such as configMap.
+func volumeSourceType(source corev1.VolumeSource) string {
+	switch {
+	case source.HostPath != nil:
+		return "hostPath"
+	case source.EmptyDir != nil:
+		return "emptyDir"
+	case source.Secret != nil:
+		return "secret"
+	case source.ConfigMap != nil:
+		return "configMap"
+	case source.PersistentVolumeClaim != nil:
+		return "persistentVolumeClaim"
+	case source.Projected != nil:
+		return "projected"
+	case source.DownwardAPI != nil:
+		return "downwardAPI"
+	case source.Ephemeral != nil:
+		return "ephemeral"
+	case source.CSI != nil:
+		return "csi"
+	case source.Image != nil:
+		return "image"
+	case source.NFS != nil:
+		return "nfs"
+	case source.ISCSI != nil:
+		return "iscsi"
+	case source.FC != nil:
+		return "fc"
+	case source.RBD != nil:
+		return "rbd"
+	case source.CephFS != nil:
+		return "cephfs"
+	case source.Cinder != nil:
+		return "cinder"
+	case source.GitRepo != nil:
+		return "gitRepo"
+	case source.Glusterfs != nil:
+		return "glusterfs"
+	case source.FlexVolume != nil:
+		return "flexVolume"
+	case source.AzureFile != nil:
+		return "azureFile"
+	case source.AzureDisk != nil:
+		return "azureDisk"
+	case source.AWSElasticBlockStore != nil:
+		return "awsElasticBlockStore"
+	case source.GCEPersistentDisk != nil:
+		return "gcePersistentDisk"
+	case source.VsphereVolume != nil:
+		return "vsphereVolume"
+	case source.Quobyte != nil:
+		return "quobyte"
+	case source.Flocker != nil:
+		return "flocker"
+	case source.PhotonPersistentDisk != nil:
+		return "photonPersistentDisk"
+	case source.PortworxVolume != nil:
+		return "portworxVolume"
+	case source.ScaleIO != nil:
+		return "scaleIO"
+	case source.StorageOS != nil:
+		return "storageos"
+	default:
+		return "unknown"
+	}
+}
+
+func securedContainers(spec *corev1.PodSpec, specPath *field.Path) []securedContainer {
+	out := make([]securedContainer, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+
+	for i, c := range spec.InitContainers {
+		out = append(out, securedContainer{Path: specPath.Child("initContainers").Index(i), SecurityContext: c.SecurityContext, VolumeMounts: c.VolumeMounts})
+	}
+
+	for i, c := range spec.Containers {
+		out = append(out, securedContainer{Path: specPath.Child("containers").Index(i), SecurityContext: c.SecurityContext, VolumeMounts: c.VolumeMounts})
+	}
+
+	for i, c := range spec.EphemeralContainers {
+		out = append(out, securedContainer{Path: specPath.Child("ephemeralContainers").Index(i), SecurityContext: c.SecurityContext, VolumeMounts: c.VolumeMounts})
+	}
+
+	return out
+}
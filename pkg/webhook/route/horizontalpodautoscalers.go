@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/horizontalpodautoscalers,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update,versions=v2,name=horizontalpodautoscalers.projectcapsule.dev
+
+type horizontalPodAutoscaler struct {
+	handlers []capsulewebhook.Handler
+}
+
+func HorizontalPodAutoscaler(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &horizontalPodAutoscaler{handlers: handler}
+}
+
+func (w *horizontalPodAutoscaler) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *horizontalPodAutoscaler) GetPath() string {
+	return "/horizontalpodautoscalers"
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/openshift-routes,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups=route.openshift.io,resources=routes,verbs=create;update,versions=v1,name=openshiftroutes.projectcapsule.dev
+
+type openShiftRoute struct {
+	handlers []capsulewebhook.Handler
+}
+
+func OpenShiftRoute(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &openShiftRoute{handlers: handler}
+}
+
+func (w *openShiftRoute) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *openShiftRoute) GetPath() string {
+	return "/openshift-routes"
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/tenantresourcegrants,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="capsule.clastix.io",resources=tenantresourcegrants,verbs=create;update,versions=v1beta2,name=tenantresourcegrants.projectcapsule.dev
+
+type tntResourceGrants struct {
+	handlers []capsulewebhook.Handler
+}
+
+func TenantResourceGrants(handlers ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &tntResourceGrants{handlers: handlers}
+}
+
+func (t tntResourceGrants) GetPath() string {
+	return "/tenantresourcegrants"
+}
+
+func (t tntResourceGrants) GetHandlers() []capsulewebhook.Handler {
+	return t.handlers
+}
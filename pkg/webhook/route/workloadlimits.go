@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/workloads/limits,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups=apps;batch,resources=deployments;deployments/scale;statefulsets;statefulsets/scale;replicasets;replicasets/scale;jobs;cronjobs,verbs=create;update,versions=v1,name=workloadlimits.projectcapsule.dev
+
+type workloadLimits struct {
+	handlers []capsulewebhook.Handler
+}
+
+func WorkloadLimits(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &workloadLimits{handlers: handler}
+}
+
+func (w *workloadLimits) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *workloadLimits) GetPath() string {
+	return "/workloads/limits"
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/workloads,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups=apps;batch,resources=deployments;statefulsets;daemonsets;replicasets;jobs;cronjobs,verbs=create;update,versions=v1,name=workloads.projectcapsule.dev
+
+type workload struct {
+	handlers []capsulewebhook.Handler
+}
+
+func Workload(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &workload{handlers: handler}
+}
+
+func (w *workload) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *workload) GetPath() string {
+	return "/workloads"
+}
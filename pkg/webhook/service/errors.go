@@ -63,3 +63,62 @@ func NewLoadBalancerDisabled() error {
 func (loadBalancerDisabledError) Error() string {
 	return "LoadBalancer service types are forbidden for the tenant: please, reach out to the system administrators"
 }
+
+type loadBalancerLimitReachedError struct {
+	limit int32
+}
+
+func NewLoadBalancerLimitReached(limit int32) error {
+	return &loadBalancerLimitReachedError{limit: limit}
+}
+
+func (e loadBalancerLimitReachedError) Error() string {
+	return fmt.Sprintf("the current Tenant reached the limit of %d LoadBalancer Services: please, reach out to the system administrators", e.limit)
+}
+
+type externalNameNotAllowedError struct {
+	externalName string
+	spec         api.AllowedListSpec
+}
+
+func NewExternalNameNotAllowed(externalName string, spec api.AllowedListSpec) error {
+	return &externalNameNotAllowedError{externalName: externalName, spec: spec}
+}
+
+func (e externalNameNotAllowedError) Error() string {
+	msg := fmt.Sprintf("external name %s is not allowed for the current Tenant", e.externalName)
+
+	if len(e.spec.Exact) > 0 {
+		msg += fmt.Sprintf(", specify one of the following (%s)", strings.Join(e.spec.Exact, ", "))
+	}
+
+	if len(e.spec.Regex) > 0 {
+		msg += fmt.Sprintf(", or matching the regex %s", e.spec.Regex)
+	}
+
+	return msg
+}
+
+type externalNameCrossTenantError struct {
+	externalName string
+}
+
+func NewExternalNameCrossTenant(externalName string) error {
+	return &externalNameCrossTenantError{externalName: externalName}
+}
+
+func (e externalNameCrossTenantError) Error() string {
+	return fmt.Sprintf("external name %s is referring to a Service of another Tenant: please, reach out to the system administrators", e.externalName)
+}
+
+type externalServiceIPInUseError struct {
+	ip string
+}
+
+func NewExternalServiceIPInUse(ip string) error {
+	return &externalServiceIPInUseError{ip: ip}
+}
+
+func (e externalServiceIPInUseError) Error() string {
+	return fmt.Sprintf("external IP %s is already in use by a Service outside the current Tenant: please, reach out to the system administrators", e.ip)
+}
@@ -0,0 +1,45 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	serviceindexer "github.com/projectcapsule/capsule/pkg/indexer/service"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// validateExternalIPsOwnership denies the external IPs already used by Services of Namespaces not belonging to the Tenant:
+// the Services of the same Tenant can share an external IP, such as when exposing different ports.
+func (r *handler) validateExternalIPsOwnership(ctx context.Context, clt client.Client, req admission.Request, recorder record.EventRecorder, tnt capsulev1beta2.Tenant, svc *corev1.Service) *admission.Response {
+	namespaces := sets.New(tnt.Status.Namespaces...)
+
+	for _, externalIP := range svc.Spec.ExternalIPs {
+		list := &corev1.ServiceList{}
+		if err := clt.List(ctx, list, client.MatchingFields{serviceindexer.ExternalIPsField: serviceindexer.CanonicalIP(externalIP)}); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		for _, other := range list.Items {
+			if namespaces.Has(other.Namespace) {
+				continue
+			}
+
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExternalServiceIPInUse", "Service %s/%s external IP %s is already in use outside the Tenant", req.Namespace, req.Name, externalIP)
+
+			response := admission.Denied(NewExternalServiceIPInUse(externalIP).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// validateExternalName denies the ExternalName Services pointing to a domain not allowed for the Tenant,
+// or to the in-cluster Services of the Namespaces of another Tenant: the latter is enforced regardless of the Service options.
+func (r *handler) validateExternalName(ctx context.Context, clt client.Client, req admission.Request, recorder record.EventRecorder, tnt capsulev1beta2.Tenant, svc *corev1.Service) *admission.Response {
+	if svc.Spec.Type != corev1.ServiceTypeExternalName {
+		return nil
+	}
+
+	externalName := strings.TrimSuffix(strings.ToLower(svc.Spec.ExternalName), ".")
+
+	if tnt.Spec.ServiceOptions == nil {
+		return r.validateExternalNameTenant(ctx, clt, req, recorder, tnt, externalName)
+	}
+
+	if err := validateExternalNameDomain(externalName, *tnt.Spec.ServiceOptions); err != nil {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalName", "Service %s/%s external name %s is forbidden for the current Tenant", req.Namespace, req.Name, externalName)
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	return r.validateExternalNameTenant(ctx, clt, req, recorder, tnt, externalName)
+}
+
+// validateExternalNameTenant denies the external names referring to the in-cluster Services of the Namespaces of another Tenant.
+func (r *handler) validateExternalNameTenant(ctx context.Context, clt client.Client, req admission.Request, recorder record.EventRecorder, tnt capsulev1beta2.Tenant, externalName string) *admission.Response {
+	namespace, ok := clusterServiceNamespace(externalName)
+	if !ok {
+		return nil
+	}
+
+	tntList := &capsulev1beta2.TenantList{}
+	if err := clt.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) > 0 && tntList.Items[0].GetName() != tnt.GetName() {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalName", "Service %s/%s external name %s is referring to another Tenant", req.Namespace, req.Name, externalName)
+
+		response := admission.Denied(NewExternalNameCrossTenant(externalName).Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+func validateExternalNameDomain(externalName string, options api.ServiceOptions) error {
+	if err := api.ValidateForbidden(map[string]string{externalName: ""}, options.ForbiddenExternalNames); err != nil {
+		return errors.Wrap(err, "service externalName validation failed")
+	}
+
+	if allowed := options.AllowedExternalNames; allowed != nil && (len(allowed.Exact) > 0 || len(allowed.Regex) > 0) && !allowed.Match(externalName) {
+		return NewExternalNameNotAllowed(externalName, *allowed)
+	}
+
+	return nil
+}
+
+// clusterServiceNamespace returns the Namespace of the in-cluster Service the given name is referring to,
+// such as oil-production for db.oil-production.svc.cluster.local: the cluster domain is not taken into account.
+func clusterServiceNamespace(externalName string) (string, bool) {
+	labels := strings.Split(externalName, ".")
+
+	for i := 2; i < len(labels); i++ {
+		if labels[i] == "svc" {
+			return labels[i-1], true
+		}
+	}
+
+	return "", false
+}
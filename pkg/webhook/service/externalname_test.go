@@ -0,0 +1,87 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+)
+
+func TestValidateExternalNameDomain(t *testing.T) {
+	options := api.ServiceOptions{
+		AllowedExternalNames:   &api.AllowedListSpec{Exact: []string{"db.acme.com"}, Regex: `\.rds\.amazonaws\.com$`},
+		ForbiddenExternalNames: api.ForbiddenListSpec{Regex: `^internal\.`},
+	}
+
+	assert.NoError(t, validateExternalNameDomain("db.acme.com", options))
+	assert.NoError(t, validateExternalNameDomain("oil.eu-west-1.rds.amazonaws.com", options))
+	assert.Error(t, validateExternalNameDomain("internal.rds.amazonaws.com", options))
+	assert.Error(t, validateExternalNameDomain("evil.com", options))
+	assert.NoError(t, validateExternalNameDomain("evil.com", api.ServiceOptions{}))
+}
+
+func TestClusterServiceNamespace(t *testing.T) {
+	for externalName, namespace := range map[string]string{
+		"db.gas-production.svc.cluster.local": "gas-production",
+		"db.gas-production.svc":               "gas-production",
+		"db.gas-production.svc.k8s.acme.com":  "gas-production",
+		"svc.acme.com":                        "",
+		"db.acme.com":                         "",
+	} {
+		actual, ok := clusterServiceNamespace(externalName)
+		assert.Equal(t, namespace, actual, externalName)
+		assert.Equal(t, namespace != "", ok, externalName)
+	}
+}
+
+func TestValidateExternalNameCrossTenant(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	indexer := tenantindexer.NamespacesReference{Obj: &capsulev1beta2.Tenant{}}
+
+	oil := capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec:       capsulev1beta2.TenantSpec{ServiceOptions: &api.ServiceOptions{}},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+	gas := capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "gas"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"gas-production"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(indexer.Object(), indexer.Field(), indexer.Func()).WithObjects(&oil, &gas).Build()
+
+	h := &handler{}
+
+	// The Tenants with no Service options are subject to the check too.
+	noOptions := *oil.DeepCopy()
+	noOptions.Spec.ServiceOptions = nil
+
+	for _, tnt := range []capsulev1beta2.Tenant{oil, noOptions} {
+		for externalName, allowed := range map[string]bool{
+			"db.oil-production.svc.cluster.local": true,
+			"db.kube-system.svc.cluster.local":    true,
+			"db.gas-production.svc.cluster.local": false,
+			"DB.GAS-PRODUCTION.SVC.":              false,
+		} {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: externalName}}
+
+			response := h.validateExternalName(context.Background(), c, admission.Request{}, record.NewFakeRecorder(1), tnt, svc)
+			assert.Equal(t, allowed, response == nil, externalName)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	serviceindexer "github.com/projectcapsule/capsule/pkg/indexer/service"
+)
+
+// validateServicePolicy returns a violation for each field of the Service not satisfying the given options.
+func validateServicePolicy(svc *corev1.Service, options api.ServiceOptions) (errs field.ErrorList) {
+	specPath := field.NewPath("spec")
+
+	exposed := svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer
+
+	if exposed && len(options.AllowedNodePortRanges) > 0 {
+		// Node ports are not allocated for the LoadBalancer Services disabling them.
+		allocated := svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.AllocateLoadBalancerNodePorts == nil || *svc.Spec.AllocateLoadBalancerNodePorts
+
+		for i, port := range svc.Spec.Ports {
+			portPath := specPath.Child("ports").Index(i).Child("nodePort")
+
+			switch {
+			case port.NodePort == 0 && allocated:
+				errs = append(errs, field.Required(portPath, "the current Tenant requires node ports to be set within the allowed ranges "+portRanges(options.AllowedNodePortRanges)))
+			case port.NodePort != 0 && !inPortRanges(port.NodePort, options.AllowedNodePortRanges):
+				errs = append(errs, field.Invalid(portPath, port.NodePort, "node port is not within the ranges allowed for the current Tenant "+portRanges(options.AllowedNodePortRanges)))
+			}
+		}
+
+		if port := svc.Spec.HealthCheckNodePort; port != 0 && !inPortRanges(port, options.AllowedNodePortRanges) {
+			errs = append(errs, field.Invalid(specPath.Child("healthCheckNodePort"), port, "node port is not within the ranges allowed for the current Tenant "+portRanges(options.AllowedNodePortRanges)))
+		}
+	}
+
+	if exposed && len(options.AllowedExternalTrafficPolicies) > 0 {
+		policy := svc.Spec.ExternalTrafficPolicy
+		if policy == "" {
+			policy = corev1.ServiceExternalTrafficPolicyCluster
+		}
+
+		if !sets.New(options.AllowedExternalTrafficPolicies...).Has(policy) {
+			allowed := make([]string, 0, len(options.AllowedExternalTrafficPolicies))
+
+			for _, p := range options.AllowedExternalTrafficPolicies {
+				allowed = append(allowed, string(p))
+			}
+
+			errs = append(errs, field.NotSupported(specPath.Child("externalTrafficPolicy"), policy, allowed))
+		}
+	}
+
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || options.LoadBalancer == nil {
+		return errs
+	}
+
+	if classes := options.LoadBalancer.AllowedClasses; len(classes) > 0 {
+		switch class := svc.Spec.LoadBalancerClass; {
+		case class == nil:
+			errs = append(errs, field.Required(specPath.Child("loadBalancerClass"), "the current Tenant requires one of the following classes ("+strings.Join(classes, ", ")+")"))
+		case !sets.New(classes...).Has(*class):
+			errs = append(errs, field.NotSupported(specPath.Child("loadBalancerClass"), *class, classes))
+		}
+	}
+
+	if allowed := options.LoadBalancer.AllowedSourceRanges; len(allowed) > 0 {
+		rangesPath := specPath.Child("loadBalancerSourceRanges")
+
+		if len(svc.Spec.LoadBalancerSourceRanges) == 0 {
+			errs = append(errs, field.Required(rangesPath, "the current Tenant requires source ranges within the allowed CIDRs"))
+		}
+
+		for i, sourceRange := range svc.Spec.LoadBalancerSourceRanges {
+			if !cidrAllowed(sourceRange, allowed) {
+				errs = append(errs, field.Invalid(rangesPath.Index(i), sourceRange, "source range is not within the CIDRs allowed for the current Tenant"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// loadBalancersCount returns the number of Services with type LoadBalancer across the Namespaces of the Tenant,
+// apart from the given one: the Services are counted using the type index.
+func loadBalancersCount(ctx context.Context, c client.Client, tnt capsulev1beta2.Tenant, svc *corev1.Service) (count int32, err error) {
+	for _, namespace := range tnt.Status.Namespaces {
+		list := &corev1.ServiceList{}
+		if err = c.List(ctx, list, client.InNamespace(namespace), client.MatchingFields{serviceindexer.TypeField: string(corev1.ServiceTypeLoadBalancer)}); err != nil {
+			return 0, err
+		}
+
+		for _, item := range list.Items {
+			if item.Namespace == svc.Namespace && item.Name == svc.Name {
+				continue
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func inPortRanges(port int32, ranges []api.PortRange) bool {
+	for _, r := range ranges {
+		if r.Contains(port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func portRanges(ranges []api.PortRange) string {
+	out := make([]string, 0, len(ranges))
+
+	for _, r := range ranges {
+		out = append(out, fmt.Sprintf("%d-%d", r.Min, r.Max))
+	}
+
+	return "(" + strings.Join(out, ", ") + ")"
+}
+
+// cidrAllowed returns true if the given CIDR is contained in one of the allowed ones.
+func cidrAllowed(cidr string, allowed []api.AllowedIP) bool {
+	network, err := api.ParseNetwork(cidr)
+	if err != nil {
+		return false
+	}
+
+	ones, bits := network.Mask.Size()
+
+	for _, a := range allowed {
+		allowedNetwork, parseErr := a.Network()
+		if parseErr != nil {
+			continue
+		}
+
+		if allowedOnes, allowedBits := allowedNetwork.Mask.Size(); allowedBits == bits && allowedOnes <= ones && allowedNetwork.Contains(network.IP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	serviceindexer "github.com/projectcapsule/capsule/pkg/indexer/service"
+)
+
+func TestValidateServicePolicy(t *testing.T) {
+	options := api.ServiceOptions{
+		AllowedNodePortRanges:          []api.PortRange{{Min: 30000, Max: 30099}},
+		AllowedExternalTrafficPolicies: []corev1.ServiceExternalTrafficPolicy{corev1.ServiceExternalTrafficPolicyLocal},
+		LoadBalancer: &api.LoadBalancerPolicy{
+			AllowedClasses:      []string{"internal"},
+			AllowedSourceRanges: []api.AllowedIP{"10.0.0.0/8"},
+		},
+	}
+
+	valid := corev1.ServiceSpec{
+		Type:                     corev1.ServiceTypeLoadBalancer,
+		Ports:                    []corev1.ServicePort{{Port: 80, NodePort: 30080}},
+		ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+		HealthCheckNodePort:      30099,
+		LoadBalancerClass:        ptr.To("internal"),
+		LoadBalancerSourceRanges: []string{"10.1.0.0/16", "10.2.3.4"},
+	}
+
+	for name, tc := range map[string]struct {
+		mutate func(spec *corev1.ServiceSpec)
+		errors int
+	}{
+		"valid":                  {func(*corev1.ServiceSpec) {}, 0},
+		"ClusterIP is unchecked": {func(spec *corev1.ServiceSpec) { *spec = corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP} }, 0},
+		"node port out of range": {func(spec *corev1.ServiceSpec) { spec.Ports[0].NodePort = 31000 }, 1},
+		"node port not set":      {func(spec *corev1.ServiceSpec) { spec.Ports[0].NodePort = 0 }, 1},
+		"node ports not allocated": {func(spec *corev1.ServiceSpec) {
+			spec.Ports[0].NodePort = 0
+			spec.AllocateLoadBalancerNodePorts = ptr.To(false)
+		}, 0},
+		"health check out of range":  {func(spec *corev1.ServiceSpec) { spec.HealthCheckNodePort = 32000 }, 1},
+		"traffic policy not allowed": {func(spec *corev1.ServiceSpec) { spec.ExternalTrafficPolicy = "" }, 1},
+		"class not set":              {func(spec *corev1.ServiceSpec) { spec.LoadBalancerClass = nil }, 1},
+		"class not allowed":          {func(spec *corev1.ServiceSpec) { spec.LoadBalancerClass = ptr.To("public") }, 1},
+		"source ranges not set":      {func(spec *corev1.ServiceSpec) { spec.LoadBalancerSourceRanges = nil }, 1},
+		"source range too wide":      {func(spec *corev1.ServiceSpec) { spec.LoadBalancerSourceRanges = []string{"0.0.0.0/0"} }, 1},
+		"source range outside":       {func(spec *corev1.ServiceSpec) { spec.LoadBalancerSourceRanges = []string{"192.168.0.0/24"} }, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := &corev1.Service{Spec: *valid.DeepCopy()}
+			tc.mutate(&svc.Spec)
+
+			assert.Len(t, validateServicePolicy(svc, options), tc.errors)
+		})
+	}
+}
+
+func TestLoadBalancersCount(t *testing.T) {
+	service := func(namespace, name string, serviceType corev1.ServiceType) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: corev1.ServiceSpec{Type: serviceType}}
+	}
+
+	indexer := serviceindexer.Type{}
+
+	c := fake.NewClientBuilder().
+		WithIndex(indexer.Object(), indexer.Field(), indexer.Func()).
+		WithObjects(
+			service("oil-production", "web", corev1.ServiceTypeLoadBalancer),
+			service("oil-development", "web", corev1.ServiceTypeLoadBalancer),
+			service("oil-development", "db", corev1.ServiceTypeClusterIP),
+			service("gas-production", "web", corev1.ServiceTypeLoadBalancer),
+		).
+		Build()
+
+	tnt := capsulev1beta2.Tenant{Status: capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production", "oil-development"}}}
+
+	count, err := loadBalancersCount(context.Background(), c, tnt, service("oil-production", "api", corev1.ServiceTypeLoadBalancer))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), count)
+
+	count, err = loadBalancersCount(context.Background(), c, tnt, service("oil-production", "web", corev1.ServiceTypeLoadBalancer))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), count)
+}
@@ -6,7 +6,6 @@ package service
 import (
 	"context"
 	"net"
-	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -70,6 +69,24 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		return &response
 	}
 
+	if tnt.Spec.ServiceOptions != nil {
+		if errs := validateServicePolicy(svc, *tnt.Spec.ServiceOptions); len(errs) > 0 {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenServicePolicy", "Service %s/%s is not satisfying the policy of the current Tenant", req.Namespace, req.Name)
+
+			response := admission.Denied(errs.ToAggregate().Error())
+
+			return &response
+		}
+
+		if response := r.validateLoadBalancerCount(ctx, clt, decoder, req, recorder, tnt, svc); response != nil {
+			return response
+		}
+	}
+
+	if response := r.validateExternalName(ctx, clt, req, recorder, tnt, svc); response != nil {
+		return response
+	}
+
 	if tnt.Spec.ServiceOptions != nil {
 		err := api.ValidateForbidden(svc.Annotations, tnt.Spec.ServiceOptions.ForbiddenAnnotations)
 		if err != nil {
@@ -90,38 +107,56 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		}
 	}
 
-	if svc.Spec.ExternalIPs == nil || (tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil) {
+	if len(svc.Spec.ExternalIPs) == 0 {
 		return nil
 	}
 
-	ipInCIDR := func(ip net.IP) bool {
-		for _, allowed := range tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed {
-			if !strings.Contains(string(allowed), "/") {
-				allowed += "/32"
-			}
+	if tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.ExternalServiceIPs != nil {
+		for _, externalIP := range svc.Spec.ExternalIPs {
+			if ip := net.ParseIP(externalIP); ip == nil || !tnt.Spec.ServiceOptions.ExternalServiceIPs.Contains(ip) {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, externalIP)
 
-			_, allowedIP, _ := net.ParseCIDR(string(allowed))
+				response := admission.Denied(NewExternalServiceIPForbidden(tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed).Error())
 
-			if allowedIP.Contains(ip) {
-				return true
+				return &response
 			}
 		}
-
-		return false
 	}
 
-	for _, externalIP := range svc.Spec.ExternalIPs {
-		ip := net.ParseIP(externalIP)
+	return r.validateExternalIPsOwnership(ctx, clt, req, recorder, tnt, svc)
+}
 
-		if !ipInCIDR(ip) {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
+// validateLoadBalancerCount denies the Service becoming of type LoadBalancer when the Tenant reached the maximum number of them:
+// the Services already of type LoadBalancer are not taken into account, allowing their update in any case.
+func (r *handler) validateLoadBalancerCount(ctx context.Context, clt client.Client, decoder admission.Decoder, req admission.Request, recorder record.EventRecorder, tnt capsulev1beta2.Tenant, svc *corev1.Service) *admission.Response {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || tnt.Spec.ServiceOptions.LoadBalancer == nil || tnt.Spec.ServiceOptions.LoadBalancer.MaxCount == nil {
+		return nil
+	}
 
-			response := admission.Denied(NewExternalServiceIPForbidden(tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed).Error())
+	if len(req.OldObject.Raw) > 0 {
+		old := &corev1.Service{}
+		if err := decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return utils.ErroredResponse(err)
+		}
 
-			return &response
+		if old.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			return nil
 		}
 	}
 
+	count, err := loadBalancersCount(ctx, clt, tnt, svc)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if limit := *tnt.Spec.ServiceOptions.LoadBalancer.MaxCount; count >= limit {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "LoadBalancerLimitReached", "Service %s/%s cannot be type of LoadBalancer, the Tenant reached the limit of %d", req.Namespace, req.Name, limit)
+
+		response := admission.Denied(NewLoadBalancerLimitReached(limit).Error())
+
+		return &response
+	}
+
 	return nil
 }
 
@@ -37,6 +37,14 @@ func (h *containerRegistryRegexHandler) validate(decoder admission.Decoder, req
 		}
 	}
 
+	if refs := tenant.Spec.ImageReferences; refs != nil && refs.DigestRequiredRegistries != nil && len(refs.DigestRequiredRegistries.Regex) > 0 {
+		if _, err := regexp.Compile(refs.DigestRequiredRegistries.Regex); err != nil {
+			response := admission.Denied("unable to compile imageReferences digestRequiredRegistries allowedRegex")
+
+			return &response
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,78 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type externalServiceIPsHandler struct{}
+
+// ExternalServiceIPsHandler validates the IP addresses and CIDRs of the Service options,
+// IPv4 and IPv6 ones, since they're not validated by the CRD schema.
+func ExternalServiceIPsHandler() capsulewebhook.Handler {
+	return &externalServiceIPsHandler{}
+}
+
+func (h *externalServiceIPsHandler) validate(decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	options := tenant.Spec.ServiceOptions
+	if options == nil {
+		return nil
+	}
+
+	toValidate := map[string][]api.AllowedIP{}
+
+	if options.ExternalServiceIPs != nil {
+		toValidate["serviceOptions.externalIPs.allowed"] = options.ExternalServiceIPs.Allowed
+	}
+
+	if options.LoadBalancer != nil {
+		toValidate["serviceOptions.loadBalancer.allowedSourceRanges"] = options.LoadBalancer.AllowedSourceRanges
+	}
+
+	for path, ips := range toValidate {
+		for _, ip := range ips {
+			if _, err := ip.Network(); err != nil {
+				response := admission.Denied(fmt.Sprintf("invalid %s: %s", path, err.Error()))
+
+				return &response
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *externalServiceIPsHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *externalServiceIPsHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *externalServiceIPsHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
@@ -29,6 +29,22 @@ func (h *forbiddenAnnotationsRegexHandler) validate(decoder admission.Decoder, r
 		return utils.ErroredResponse(err)
 	}
 
+	if options := tenant.Spec.IngressOptions; len(options.ForbiddenAnnotations.Regex) > 0 {
+		if _, err := regexp.Compile(options.ForbiddenAnnotations.Regex); err != nil {
+			response := admission.Denied("unable to compile ingressOptions forbiddenAnnotations deniedRegex")
+
+			return &response
+		}
+	}
+
+	if options := tenant.Spec.IngressOptions; options.AllowedAnnotations != nil && len(options.AllowedAnnotations.Regex) > 0 {
+		if _, err := regexp.Compile(options.AllowedAnnotations.Regex); err != nil {
+			response := admission.Denied("unable to compile ingressOptions allowedAnnotations allowedRegex")
+
+			return &response
+		}
+	}
+
 	if tenant.Spec.NamespaceOptions == nil {
 		return nil
 	}
@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type hostnameClaimsHandler struct{}
+
+func HostnameClaimsHandler() capsulewebhook.Handler {
+	return &hostnameClaimsHandler{}
+}
+
+// validate denies hostname claims overlapping with the ones of other Tenants, since a hostname can be reserved to a single Tenant.
+func (h *hostnameClaimsHandler) validate(ctx context.Context, c client.Client, decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tenant.Spec.IngressOptions.HostnameClaims) == 0 {
+		return nil
+	}
+
+	tenants := &capsulev1beta2.TenantList{}
+	if err := c.List(ctx, tenants); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	for _, claim := range tenant.Spec.IngressOptions.HostnameClaims {
+		for _, other := range tenants.Items {
+			if other.GetName() == tenant.GetName() {
+				continue
+			}
+
+			for _, otherClaim := range other.Spec.IngressOptions.HostnameClaims {
+				if claim.Overlaps(otherClaim) {
+					response := admission.Denied(fmt.Sprintf("hostname claim %s is overlapping with the claim %s of the Tenant %s", claim, otherClaim, other.GetName()))
+
+					return &response
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *hostnameClaimsHandler) OnCreate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, req)
+	}
+}
+
+func (h *hostnameClaimsHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *hostnameClaimsHandler) OnUpdate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, req)
+	}
+}
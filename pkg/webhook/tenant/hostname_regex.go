@@ -37,6 +37,42 @@ func (h *hostnameRegexHandler) validate(decoder admission.Decoder, req admission
 		}
 	}
 
+	if options := tenant.Spec.GatewayOptions; options != nil {
+		if options.AllowedHostnames != nil && len(options.AllowedHostnames.Regex) > 0 {
+			if _, err := regexp.Compile(options.AllowedHostnames.Regex); err != nil {
+				response := admission.Denied("unable to compile gatewayOptions allowedHostnames allowedRegex")
+
+				return &response
+			}
+		}
+
+		if options.AllowedClasses != nil && len(options.AllowedClasses.Regex) > 0 {
+			if _, err := regexp.Compile(options.AllowedClasses.Regex); err != nil {
+				response := admission.Denied("unable to compile gatewayOptions allowedClasses allowedRegex")
+
+				return &response
+			}
+		}
+	}
+
+	if options := tenant.Spec.ServiceOptions; options != nil {
+		if options.AllowedExternalNames != nil && len(options.AllowedExternalNames.Regex) > 0 {
+			if _, err := regexp.Compile(options.AllowedExternalNames.Regex); err != nil {
+				response := admission.Denied("unable to compile serviceOptions allowedExternalNames allowedRegex")
+
+				return &response
+			}
+		}
+
+		if len(options.ForbiddenExternalNames.Regex) > 0 {
+			if _, err := regexp.Compile(options.ForbiddenExternalNames.Regex); err != nil {
+				response := admission.Denied("unable to compile serviceOptions forbiddenExternalNames deniedRegex")
+
+				return &response
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,74 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type imagePullPolicyRulesHandler struct{}
+
+// ImagePullPolicyRulesHandler validates the default pull policy of the image pull policy rules:
+// it must be one of the allowed ones, otherwise the defaulted containers would be rejected.
+func ImagePullPolicyRulesHandler() capsulewebhook.Handler {
+	return &imagePullPolicyRulesHandler{}
+}
+
+func (h *imagePullPolicyRulesHandler) validate(decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	for i, rule := range tenant.Spec.ImagePullPolicyRules {
+		if len(rule.Default) == 0 {
+			continue
+		}
+
+		allowed := false
+
+		for _, policy := range rule.AllowedPolicies {
+			if policy == rule.Default {
+				allowed = true
+
+				break
+			}
+		}
+
+		if !allowed {
+			response := admission.Denied(fmt.Sprintf("imagePullPolicyRules[%d].default %s is not one of the allowed policies %v", i, rule.Default, rule.AllowedPolicies))
+
+			return &response
+		}
+	}
+
+	return nil
+}
+
+func (h *imagePullPolicyRulesHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *imagePullPolicyRulesHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *imagePullPolicyRulesHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type ingressDefaultsHandler struct{}
+
+// IngressDefaultsHandler validates the Secret name template of the default Ingress TLS block:
+// the template is rendered with sample Namespace and Ingress names, and the result must be a valid DNS-1123 subdomain.
+func IngressDefaultsHandler() capsulewebhook.Handler {
+	return &ingressDefaultsHandler{}
+}
+
+func (h *ingressDefaultsHandler) validate(decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	defaults := tenant.Spec.IngressOptions.Defaults
+	if defaults == nil || defaults.TLS == nil {
+		return nil
+	}
+
+	secretName, err := defaults.TLS.SecretName(tenant.GetName(), "namespace", "ingress")
+	if err != nil {
+		response := admission.Denied(fmt.Sprintf("invalid ingressOptions.defaults.tls.secretNameTemplate: %s", err.Error()))
+
+		return &response
+	}
+
+	if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+		response := admission.Denied(fmt.Sprintf("invalid ingressOptions.defaults.tls.secretNameTemplate, the rendered Secret name %s is not valid: %s", secretName, strings.Join(errs, ", ")))
+
+		return &response
+	}
+
+	return nil
+}
+
+func (h *ingressDefaultsHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *ingressDefaultsHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *ingressDefaultsHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type pathPrefixesHandler struct{}
+
+func PathPrefixesHandler() capsulewebhook.Handler {
+	return &pathPrefixesHandler{}
+}
+
+// validate denies path prefixes overlapping with the ones of other Tenants on the same shared hostname,
+// since a path prefix can be assigned to a single Tenant.
+func (h *pathPrefixesHandler) validate(ctx context.Context, c client.Client, decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tenant.Spec.IngressOptions.AllowedPathPrefixes) == 0 {
+		return nil
+	}
+
+	tenants := &capsulev1beta2.TenantList{}
+	if err := c.List(ctx, tenants); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	for _, allowed := range tenant.Spec.IngressOptions.AllowedPathPrefixes {
+		for _, other := range tenants.Items {
+			if other.GetName() == tenant.GetName() {
+				continue
+			}
+
+			for _, otherAllowed := range other.Spec.IngressOptions.AllowedPathPrefixes {
+				if prefix, otherPrefix, ok := allowed.Overlaps(otherAllowed); ok {
+					response := admission.Denied(fmt.Sprintf("path prefix %s on hostname %s is overlapping with the path prefix %s of the Tenant %s", prefix, allowed.Hostname, otherPrefix, other.GetName()))
+
+					return &response
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *pathPrefixesHandler) OnCreate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, req)
+	}
+}
+
+func (h *pathPrefixesHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *pathPrefixesHandler) OnUpdate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, req)
+	}
+}
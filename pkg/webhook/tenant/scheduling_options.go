@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type schedulingOptionsHandler struct{}
+
+// SchedulingOptionsHandler validates the selector of the dedicated nodes:
+// an empty selector is denied, since it would dedicate all the nodes of the cluster to the Tenant.
+func SchedulingOptionsHandler() capsulewebhook.Handler {
+	return &schedulingOptionsHandler{}
+}
+
+func (h *schedulingOptionsHandler) validate(decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	options := tenant.Spec.SchedulingOptions
+	if options == nil || options.DedicatedNodes == nil {
+		return nil
+	}
+
+	selector, err := options.DedicatedNodes.NodeSelector()
+	if err != nil {
+		response := admission.Denied(fmt.Sprintf("invalid schedulingOptions.dedicatedNodes.selector: %s", err.Error()))
+
+		return &response
+	}
+
+	if selector.Empty() {
+		response := admission.Denied("schedulingOptions.dedicatedNodes.selector cannot be empty, since it would select all the nodes")
+
+		return &response
+	}
+
+	return nil
+}
+
+func (h *schedulingOptionsHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *schedulingOptionsHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *schedulingOptionsHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type grantsHandler struct{}
+
+// GrantsHandler ensures the TenantResourceGrant objects are living in a Namespace of the granting Tenant:
+// the grants outside any Tenant would allow the selection of objects not belonging to any Tenant.
+// The selectors of the granted resources are validated, since the invalid ones would be ignored.
+func GrantsHandler() capsulewebhook.Handler {
+	return &grantsHandler{}
+}
+
+func (h *grantsHandler) validate(ctx context.Context, clt client.Client, decoder admission.Decoder, req admission.Request) *admission.Response {
+	grant := &capsulev1beta2.TenantResourceGrant{}
+	if err := decoder.Decode(req, grant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, clt, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt.GetName() == "" {
+		response := admission.Denied(fmt.Sprintf("TenantResourceGrant must be created in a Namespace of the granting Tenant, %s does not belong to any Tenant", req.Namespace))
+
+		return &response
+	}
+
+	for _, consumer := range grant.Spec.Tenants {
+		if consumer == tnt.GetName() {
+			response := admission.Denied(fmt.Sprintf("TenantResourceGrant cannot grant resources to the owning Tenant %s", consumer))
+
+			return &response
+		}
+	}
+
+	for _, resource := range grant.Spec.Resources {
+		if resource.Selector == nil {
+			continue
+		}
+
+		if _, err = metav1.LabelSelectorAsSelector(resource.Selector); err != nil {
+			response := admission.Denied(fmt.Sprintf("TenantResourceGrant has an invalid selector for the %s resources: %s", resource.Kind, err.Error()))
+
+			return &response
+		}
+	}
+
+	return nil
+}
+
+func (h *grantsHandler) OnCreate(clt client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, clt, decoder, req)
+	}
+}
+
+func (h *grantsHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *grantsHandler) OnUpdate(clt client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, clt, decoder, req)
+	}
+}
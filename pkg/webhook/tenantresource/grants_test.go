@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+)
+
+func TestGrantsHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	indexer := tenantindexer.NamespacesReference{Obj: &capsulev1beta2.Tenant{}}
+
+	oil := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(indexer.Object(), indexer.Field(), indexer.Func()).WithObjects(oil).Build()
+
+	request := func(namespace string, tenants []string, resources []capsulev1beta2.GrantedResource) admission.Request {
+		data, err := json.Marshal(&capsulev1beta2.TenantResourceGrant{
+			TypeMeta:   metav1.TypeMeta{APIVersion: capsulev1beta2.GroupVersion.String(), Kind: "TenantResourceGrant"},
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: namespace},
+			Spec:       capsulev1beta2.TenantResourceGrantSpec{Tenants: tenants, Resources: resources},
+		})
+		assert.NoError(t, err)
+
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: capsulev1beta2.GroupVersion.Group, Version: capsulev1beta2.GroupVersion.Version, Kind: "TenantResourceGrant"},
+			Namespace: namespace,
+			Name:      "shared",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: data},
+		}}
+	}
+
+	secrets := func(selector *metav1.LabelSelector) []capsulev1beta2.GrantedResource {
+		return []capsulev1beta2.GrantedResource{{Kind: "Secret", Selector: selector}}
+	}
+
+	validate := GrantsHandler().OnCreate(c, admission.NewDecoder(scheme), record.NewFakeRecorder(10))
+
+	for name, tc := range map[string]struct {
+		req     admission.Request
+		allowed bool
+	}{
+		"granted to another Tenant": {request("oil-production", []string{"gas"}, secrets(nil)), true},
+		"valid selector": {request("oil-production", []string{"gas"}, secrets(&metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "shared", Operator: metav1.LabelSelectorOpExists}},
+		})), true},
+		"outside any Tenant":   {request("kube-system", []string{"gas"}, secrets(nil)), false},
+		"granted to the owner": {request("oil-production", []string{"oil"}, secrets(nil)), false},
+		"invalid selector": {request("oil-production", []string{"gas"}, secrets(&metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "shared", Operator: metav1.LabelSelectorOpIn}},
+		})), false},
+	} {
+		response := validate(context.Background(), tc.req)
+		assert.Equal(t, tc.allowed, response == nil, name)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,7 +27,7 @@ func WriteOpsHandler() capsulewebhook.Handler {
 	return &cordoningHandler{}
 }
 
-func (h *cordoningHandler) handler(ctx context.Context, clt client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+func (h *cordoningHandler) handler(ctx context.Context, clt client.Client, decoder admission.Decoder, req admission.Request, recorder record.EventRecorder) *admission.Response {
 	tntList := &capsulev1beta2.TenantList{}
 
 	if err := clt.List(ctx, tntList, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace)}); err != nil {
@@ -37,6 +38,16 @@ func (h *cordoningHandler) handler(ctx context.Context, clt client.Client, req a
 	if len(tntList.Items) == 0 {
 		return nil
 	}
+	// Objects replicated with a non enforcing SyncPolicy belong to the Tenant:
+	// these can be freely changed by Tenant users.
+	obj := &unstructured.Unstructured{}
+	if err := decoder.DecodeRaw(req.OldObject, obj); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if policy := capsulev1beta2.SyncPolicy(obj.GetAnnotations()[capsulev1beta2.SyncPolicyAnnotation]); !policy.IsEnforced() {
+		return nil
+	}
 	// Checking if the object is managed by a TenantResource, local or global
 	ors := capsulev1beta2.ObjectReferenceStatus{
 		ObjectReferenceAbstract: capsulev1beta2.ObjectReferenceAbstract{
@@ -76,14 +87,14 @@ func (h *cordoningHandler) OnCreate(client.Client, admission.Decoder, record.Eve
 	}
 }
 
-func (h *cordoningHandler) OnDelete(client client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+func (h *cordoningHandler) OnDelete(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		return h.handler(ctx, client, req, recorder)
+		return h.handler(ctx, client, decoder, req, recorder)
 	}
 }
 
-func (h *cordoningHandler) OnUpdate(client client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+func (h *cordoningHandler) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		return h.handler(ctx, client, req, recorder)
+		return h.handler(ctx, client, decoder, req, recorder)
 	}
 }
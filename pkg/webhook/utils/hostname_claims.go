@@ -0,0 +1,35 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	tenantindexer "github.com/projectcapsule/capsule/pkg/indexer/tenant"
+)
+
+// HostnameClaimedByOtherTenant returns the first of the given hostnames claimed by a Tenant other than the given one, if any.
+// Wildcard hostnames are covering all the claims of their subdomains: the claiming Tenants are retrieved from the cache by claim.
+func HostnameClaimedByOtherTenant(ctx context.Context, c client.Client, tenant string, hostnames []string) (string, error) {
+	for _, hostname := range hostnames {
+		for _, value := range api.HostnameClaim(hostname).OverlappingIndexValues() {
+			tenants := &capsulev1beta2.TenantList{}
+			if err := c.List(ctx, tenants, client.MatchingFields{tenantindexer.HostnameClaimsField: value}); err != nil {
+				return "", err
+			}
+
+			for _, tnt := range tenants.Items {
+				if tnt.GetName() != tenant {
+					return hostname, nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
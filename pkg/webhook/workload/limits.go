@@ -0,0 +1,75 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type limits struct{}
+
+func Limits() capsulewebhook.Handler {
+	return &limits{}
+}
+
+func (h *limits) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *limits) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *limits) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *limits) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt == nil || tnt.Spec.WorkloadLimits == nil {
+		return nil
+	}
+
+	current, err := workloadScale(decoder, req.Kind.Kind, req.Object)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	var old *scale
+
+	if req.Operation == admissionv1.Update {
+		if old, err = workloadScale(decoder, req.Kind.Kind, req.OldObject); err != nil {
+			return utils.ErroredResponse(err)
+		}
+	}
+
+	if errs := validateWorkloadLimits(current, old, *tnt.Spec.WorkloadLimits); len(errs) > 0 {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "WorkloadLimitExceeded", "%s %s/%s is exceeding the workload limits of the current Tenant", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := admission.Denied(errs.ToAggregate().Error())
+
+		return &response
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestScheduleInterval(t *testing.T) {
+	for schedule, expected := range map[string]time.Duration{
+		"*/5 * * * *":                   5 * time.Minute,
+		"0,50 * * * *":                  10 * time.Minute,
+		"0 * * * *":                     time.Hour,
+		"@hourly":                       time.Hour,
+		"30 2 * * *":                    24 * time.Hour,
+		"CRON_TZ=Europe/Rome 0 0 * * *": 24 * time.Hour,
+		"0 22 * * 1-5":                  24 * time.Hour,
+		"0 0 * * mon":                   7 * 24 * time.Hour,
+		"0 0 1,15 * 1":                  24 * time.Hour,
+		"0 0 31 * *":                    31 * 24 * time.Hour,
+		"@every 90s":                    90 * time.Second,
+	} {
+		interval, err := scheduleInterval(schedule)
+		require.NoError(t, err, schedule)
+		assert.Equal(t, expected, interval, schedule)
+	}
+
+	for _, schedule := range []string{"* * * *", "61 * * * *", "*/0 * * * *", "@often"} {
+		_, err := scheduleInterval(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
+
+func TestValidateWorkloadLimits(t *testing.T) {
+	limits := api.WorkloadLimitsSpec{
+		MaxReplicas:        ptr.To[int32](10),
+		MaxJobParallelism:  ptr.To[int32](5),
+		MinCronJobInterval: &metav1.Duration{Duration: 15 * time.Minute},
+	}
+
+	assert.Empty(t, validateWorkloadLimits(&scale{Replicas: ptr.To[int32](10)}, nil, limits))
+	assert.Len(t, validateWorkloadLimits(&scale{Replicas: ptr.To[int32](11)}, nil, limits), 1)
+	// Replicas exceeding the limit, but not increased, are allowed.
+	assert.Empty(t, validateWorkloadLimits(&scale{Replicas: ptr.To[int32](20)}, &scale{Replicas: ptr.To[int32](30)}, limits))
+	assert.Len(t, validateWorkloadLimits(&scale{Replicas: ptr.To[int32](31)}, &scale{Replicas: ptr.To[int32](30)}, limits), 1)
+
+	cronJob := &scale{Parallelism: ptr.To[int32](6), Schedule: "*/5 * * * *", JobSpecPath: field.NewPath("spec", "jobTemplate", "spec")}
+
+	errs := validateWorkloadLimits(cronJob, nil, limits)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.jobTemplate.spec.parallelism", errs[0].Field)
+	assert.Equal(t, "spec.schedule", errs[1].Field)
+	// Unchanged schedules are allowed.
+	assert.Empty(t, validateWorkloadLimits(cronJob, cronJob, limits))
+}
+
+func TestWorkloadScale(t *testing.T) {
+	decoder := admission.NewDecoder(clientgoscheme.Scheme)
+
+	// Bare ReplicaSets are bounded as the Deployments owning them.
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		Spec:     appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](20)},
+	}
+
+	raw, err := json.Marshal(replicaSet)
+	require.NoError(t, err)
+
+	current, err := workloadScale(decoder, "ReplicaSet", runtime.RawExtension{Raw: raw})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](20), current.Replicas)
+	assert.Len(t, validateWorkloadLimits(current, nil, api.WorkloadLimitsSpec{MaxReplicas: ptr.To[int32](10)}), 1)
+
+	_, err = workloadScale(decoder, "DaemonSet", runtime.RawExtension{Raw: []byte(`{}`)})
+	assert.Error(t, err)
+}
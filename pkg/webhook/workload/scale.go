@@ -0,0 +1,137 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// scale gathers the fields of the workloads subject to the Tenant limits:
+// the ones not applying to the admitted kind are left empty.
+type scale struct {
+	Replicas           *int32
+	AutoscalerReplicas *int32
+	Parallelism        *int32
+	Completions        *int32
+	Schedule           string
+	// JobSpecPath is the field path of the Job specification, within Jobs and CronJobs.
+	JobSpecPath *field.Path
+}
+
+// workloadScale decodes the given object, according to the admitted kind.
+func workloadScale(decoder admission.Decoder, kind string, raw runtime.RawExtension) (*scale, error) {
+	switch kind {
+	case "Deployment":
+		obj := &appsv1.Deployment{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{Replicas: obj.Spec.Replicas}, nil
+	case "StatefulSet":
+		obj := &appsv1.StatefulSet{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{Replicas: obj.Spec.Replicas}, nil
+	case "ReplicaSet":
+		obj := &appsv1.ReplicaSet{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{Replicas: obj.Spec.Replicas}, nil
+	case "Scale":
+		obj := &autoscalingv1.Scale{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{Replicas: &obj.Spec.Replicas}, nil
+	case "HorizontalPodAutoscaler":
+		obj := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{AutoscalerReplicas: &obj.Spec.MaxReplicas}, nil
+	case "Job":
+		obj := &batchv1.Job{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{Parallelism: obj.Spec.Parallelism, Completions: obj.Spec.Completions, JobSpecPath: field.NewPath("spec")}, nil
+	case "CronJob":
+		obj := &batchv1.CronJob{}
+		if err := decoder.DecodeRaw(raw, obj); err != nil {
+			return nil, err
+		}
+
+		return &scale{
+			Parallelism: obj.Spec.JobTemplate.Spec.Parallelism,
+			Completions: obj.Spec.JobTemplate.Spec.Completions,
+			Schedule:    obj.Spec.Schedule,
+			JobSpecPath: field.NewPath("spec", "jobTemplate", "spec"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s, cannot extract the workload scale", kind)
+	}
+}
+
+// validateWorkloadLimits returns a violation for each field exceeding the given limits.
+// Upon update, the values which are not increased are allowed, letting the workloads created before the limits be still managed.
+func validateWorkloadLimits(current, old *scale, limits api.WorkloadLimitsSpec) (errs field.ErrorList) {
+	if old == nil {
+		old = &scale{}
+	}
+
+	if exceeds(current.Replicas, old.Replicas, limits.MaxReplicas) {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "replicas"), *current.Replicas, fmt.Sprintf("must be less than or equal to %d for the current Tenant", *limits.MaxReplicas)))
+	}
+
+	if exceeds(current.AutoscalerReplicas, old.AutoscalerReplicas, limits.MaxAutoscalerReplicas) {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "maxReplicas"), *current.AutoscalerReplicas, fmt.Sprintf("must be less than or equal to %d for the current Tenant", *limits.MaxAutoscalerReplicas)))
+	}
+
+	if exceeds(current.Parallelism, old.Parallelism, limits.MaxJobParallelism) {
+		errs = append(errs, field.Invalid(current.JobSpecPath.Child("parallelism"), *current.Parallelism, fmt.Sprintf("must be less than or equal to %d for the current Tenant", *limits.MaxJobParallelism)))
+	}
+
+	if exceeds(current.Completions, old.Completions, limits.MaxJobCompletions) {
+		errs = append(errs, field.Invalid(current.JobSpecPath.Child("completions"), *current.Completions, fmt.Sprintf("must be less than or equal to %d for the current Tenant", *limits.MaxJobCompletions)))
+	}
+
+	if minInterval := limits.MinCronJobInterval; minInterval != nil && current.Schedule != "" && current.Schedule != old.Schedule {
+		interval, err := scheduleInterval(current.Schedule)
+
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(field.NewPath("spec", "schedule"), current.Schedule, err.Error()))
+		case interval < minInterval.Duration:
+			errs = append(errs, field.Invalid(field.NewPath("spec", "schedule"), current.Schedule, fmt.Sprintf("runs every %s, the current Tenant requires at least %s between two runs", interval, minInterval.Duration)))
+		}
+	}
+
+	return errs
+}
+
+func exceeds(value, old, limit *int32) bool {
+	if value == nil || limit == nil || *value <= *limit {
+		return false
+	}
+
+	return old == nil || *value > *old
+}
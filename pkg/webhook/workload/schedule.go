@@ -0,0 +1,199 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleWindowDays is the amount of days the schedule is evaluated on: four years, starting from a leap one,
+// are covering all the calendar combinations.
+const scheduleWindowDays = 4*365 + 1
+
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type scheduleField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = scheduleField{min: 0, max: 59}
+	hourField   = scheduleField{min: 0, max: 23}
+	domField    = scheduleField{min: 1, max: 31}
+	monthField  = scheduleField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Sunday can be expressed both as 0 and 7.
+	dowField = scheduleField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// scheduleInterval returns the shortest interval between two consecutive runs of the given CronJob schedule,
+// following the standard cron syntax supported by Kubernetes. Schedules never running, or running once
+// in the evaluated window, are returning the maximum duration.
+func scheduleInterval(schedule string) (time.Duration, error) {
+	fields := strings.Fields(schedule)
+	// The time zone doesn't affect the interval, besides daylight saving time transitions.
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		fields = fields[1:]
+	}
+
+	if len(fields) == 2 && fields[0] == "@every" {
+		return time.ParseDuration(fields[1])
+	}
+
+	if len(fields) == 1 {
+		expanded, ok := scheduleDescriptors[fields[0]]
+		if !ok {
+			return 0, fmt.Errorf("unrecognized descriptor %s", fields[0])
+		}
+
+		fields = strings.Fields(expanded)
+	}
+
+	if len(fields) != 5 {
+		return 0, fmt.Errorf("expected 5 fields, found %d", len(fields))
+	}
+
+	var (
+		sets = make([][]bool, 0, len(fields))
+		err  error
+	)
+
+	for i, f := range []scheduleField{minuteField, hourField, domField, monthField, dowField} {
+		var set []bool
+
+		if set, err = f.parse(fields[i]); err != nil {
+			return 0, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	minutes, hours, doms, months, dows := sets[0], sets[1], sets[2], sets[3], sets[4]
+	dows[0] = dows[0] || dows[7]
+	// As for cron, when both the day of the month and the day of the week are restricted, either of them has to match.
+	restrictedDays := !isUnrestricted(fields[2]) && !isUnrestricted(fields[4])
+
+	var times []int
+
+	for h := 0; h < 24; h++ {
+		for m := 0; m < 60; m++ {
+			if hours[h] && minutes[m] {
+				times = append(times, h*60+m)
+			}
+		}
+	}
+
+	shortest := math.MaxInt
+
+	for i := 1; i < len(times); i++ {
+		shortest = min(shortest, times[i]-times[i-1])
+	}
+
+	lastDay := -1
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for day := 0; day < scheduleWindowDays; day++ {
+		date := start.AddDate(0, 0, day)
+
+		domMatch, dowMatch := doms[date.Day()], dows[int(date.Weekday())]
+		if !months[int(date.Month())] || (restrictedDays && !domMatch && !dowMatch) || (!restrictedDays && (!domMatch || !dowMatch)) {
+			continue
+		}
+
+		if lastDay >= 0 {
+			shortest = min(shortest, (day-lastDay)*24*60-times[len(times)-1]+times[0])
+		}
+
+		lastDay = day
+	}
+
+	if shortest == math.MaxInt {
+		return time.Duration(math.MaxInt64), nil
+	}
+
+	return time.Duration(shortest) * time.Minute, nil
+}
+
+func isUnrestricted(expression string) bool {
+	return strings.HasPrefix(expression, "*") || strings.HasPrefix(expression, "?")
+}
+
+// parse returns the values matching the given expression, indexed by value.
+func (f scheduleField) parse(expression string) ([]bool, error) {
+	set := make([]bool, f.max+1)
+
+	for _, item := range strings.Split(expression, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %s", item)
+			}
+		}
+
+		low, high := f.min, f.max
+
+		if rangeExpr != "*" && rangeExpr != "?" {
+			lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+
+			var err error
+
+			if low, err = f.value(lowExpr); err != nil {
+				return nil, err
+			}
+
+			switch {
+			case isRange:
+				if high, err = f.value(highExpr); err != nil {
+					return nil, err
+				}
+			case !hasStep:
+				high = low
+			}
+		}
+
+		if low > high {
+			return nil, fmt.Errorf("invalid range in %s", item)
+		}
+
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+
+	return set, nil
+}
+
+func (f scheduleField) value(expression string) (int, error) {
+	if v, ok := f.names[strings.ToLower(expression)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(expression)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %s, expected within %d and %d", expression, f.min, f.max)
+	}
+
+	return v, nil
+}